@@ -16,6 +16,15 @@ type instruction struct {
 
 // Decode reads provided data and disasseles hex values to 8080 instructions
 func Decode(data []byte) error {
+	return decode(data, opcodes8080)
+}
+
+// Decode8085 reads provided data and disassembles hex values to 8085 instructions
+func Decode8085(data []byte) error {
+	return decode(data, opcodes8085)
+}
+
+func decode(data []byte, opcodes map[string]*instruction) error {
 	buffer := bytes.NewBuffer(data)
 	var ordinal int64
 
@@ -24,7 +33,7 @@ func Decode(data []byte) error {
 		if singleByte == "" {
 			break
 		}
-		inst, err := decodeSingleHex(singleByte, buffer)
+		inst, err := decodeSingleHex(singleByte, buffer, opcodes)
 		if err != nil {
 			return err
 		}
@@ -55,7 +64,7 @@ func newInstruction(name string, size int, flags, function string) *instruction
 }
 
 func (in *instruction) hasArgs() bool {
-	return in.size > 1
+	return len(in.args) > 0
 }
 
 func (in *instruction) print(ordinal int64) {
@@ -67,7 +76,7 @@ func (in *instruction) print(ordinal int64) {
 	}
 }
 
-func decodeSingleHex(hex string, buf *bytes.Buffer) (*instruction, error) {
+func decodeSingleHex(hex string, buf *bytes.Buffer, opcodes map[string]*instruction) (*instruction, error) {
 	in, ok := opcodes[hex]
 	if !ok {
 		return nil, fmt.Errorf("no opcode %q found", hex)
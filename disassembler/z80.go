@@ -0,0 +1,344 @@
+package disassembler
+
+import (
+	"fmt"
+)
+
+// Zilog mnemonic parts indexed with fields of opcode bits
+var (
+	z80R     = [...]string{"B", "C", "D", "E", "H", "L", "(HL)", "A"}
+	z80RP    = [...]string{"BC", "DE", "HL", "SP"}
+	z80RP2   = [...]string{"BC", "DE", "HL", "AF"}
+	z80CC    = [...]string{"NZ", "Z", "NC", "C", "PO", "PE", "P", "M"}
+	z80ALU   = [...]string{"ADD A,", "ADC A,", "SUB ", "SBC A,", "AND ", "XOR ", "OR ", "CP "}
+	z80Rot   = [...]string{"RLC", "RRC", "RL", "RR", "SLA", "SRA", "SLL", "SRL"}
+	z80RotA  = [...]string{"RLCA", "RRCA", "RLA", "RRA", "DAA", "CPL", "SCF", "CCF"}
+	z80IM    = [...]string{"0", "0/1", "1", "2", "0", "0/1", "1", "2"}
+	z80Block = [4][4]string{
+		{"LDI", "CPI", "INI", "OUTI"},
+		{"LDD", "CPD", "IND", "OUTD"},
+		{"LDIR", "CPIR", "INIR", "OTIR"},
+		{"LDDR", "CPDR", "INDR", "OTDR"},
+	}
+	z80EDMisc = [...]string{"LD I,A", "LD R,A", "LD A,I", "LD A,R", "RRD", "RLD", "*NOP", "*NOP"}
+)
+
+// DecodeZ80 reads provided data and disassembles it to Z80 instructions with Zilog mnemonics;
+// operands are printed as args in the order they appear in the mnemonic
+func DecodeZ80(data []byte) error {
+	var ordinal int64
+
+	for int(ordinal) < len(data) {
+		inst, err := decodeZ80(data[ordinal:])
+		if err != nil {
+			return fmt.Errorf("at %#04x: %s", ordinal, err.Error())
+		}
+		inst.print(ordinal)
+		ordinal += int64(inst.size)
+	}
+
+	return nil
+}
+
+// z80Decoder reads a single instruction; index is IX or IY after DD or FD prefix
+type z80Decoder struct {
+	data  []byte
+	pos   int
+	args  []string
+	index string
+	err   error
+}
+
+func decodeZ80(data []byte) (*instruction, error) {
+	dec := &z80Decoder{data: data}
+	name := dec.instruction()
+	if dec.err != nil {
+		return nil, dec.err
+	}
+
+	return &instruction{name: name, size: dec.pos, args: dec.args}, nil
+}
+
+func (dec *z80Decoder) next() uint8 {
+	if dec.pos >= len(dec.data) {
+		dec.err = fmt.Errorf("instruction is cut off")
+		return 0
+	}
+
+	val := dec.data[dec.pos]
+	dec.pos++
+	return val
+}
+
+// n reads 8bit immediate value
+func (dec *z80Decoder) n() string {
+	dec.args = append(dec.args, fmt.Sprintf("%02x", dec.next()))
+	return "n"
+}
+
+// nn reads 16bit immediate value
+func (dec *z80Decoder) nn() string {
+	lo := dec.next()
+	hi := dec.next()
+	dec.args = append(dec.args, fmt.Sprintf("%02x%02x", hi, lo))
+	return "nn"
+}
+
+// e reads relative jump offset
+func (dec *z80Decoder) e() string {
+	dec.args = append(dec.args, fmt.Sprintf("%02x", dec.next()))
+	return "e"
+}
+
+// hl returns HL or index register substituting it
+func (dec *z80Decoder) hl() string {
+	if dec.index != "" {
+		return dec.index
+	}
+	return "HL"
+}
+
+// r returns register encoded in opcode; with index prefix H and L stand for halves of the index
+// register and (HL) for indexed memory with displacement read from data
+func (dec *z80Decoder) r(code uint8) string {
+	if dec.index == "" {
+		return z80R[code]
+	}
+
+	switch code {
+	case 4:
+		return dec.index + "H"
+	case 5:
+		return dec.index + "L"
+	case 6:
+		dec.args = append(dec.args, fmt.Sprintf("%02x", dec.next()))
+		return "(" + dec.index + "+d)"
+	}
+	return z80R[code]
+}
+
+func (dec *z80Decoder) rp(code uint8) string {
+	if code == 2 {
+		return dec.hl()
+	}
+	return z80RP[code]
+}
+
+func (dec *z80Decoder) rp2(code uint8) string {
+	if code == 2 {
+		return dec.hl()
+	}
+	return z80RP2[code]
+}
+
+func (dec *z80Decoder) instruction() string {
+	opCode := dec.next()
+
+	switch opCode {
+	case 0xdd, 0xfd:
+		if dec.pos < len(dec.data) {
+			switch dec.data[dec.pos] {
+			case 0xdd, 0xed, 0xfd:
+				return "*NOP" // prefix followed by another one has no effect
+			}
+		}
+
+		dec.index = "IX"
+		if opCode == 0xfd {
+			dec.index = "IY"
+		}
+		opCode = dec.next()
+		if opCode == 0xcb {
+			return dec.indexedCB()
+		}
+		return dec.main(opCode)
+	case 0xcb:
+		return dec.cb(dec.next())
+	case 0xed:
+		return dec.ed(dec.next())
+	}
+
+	return dec.main(opCode)
+}
+
+func (dec *z80Decoder) main(opCode uint8) string {
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+	p, q := y>>1, y&1
+
+	switch x {
+	case 0:
+		switch z {
+		case 0:
+			switch y {
+			case 0:
+				return "NOP"
+			case 1:
+				return "EX AF,AF'"
+			case 2:
+				return "DJNZ " + dec.e()
+			case 3:
+				return "JR " + dec.e()
+			}
+			return "JR " + z80CC[y-4] + "," + dec.e()
+		case 1:
+			if q == 0 {
+				return "LD " + dec.rp(p) + "," + dec.nn()
+			}
+			return "ADD " + dec.hl() + "," + dec.rp(p)
+		case 2:
+			switch y {
+			case 0:
+				return "LD (BC),A"
+			case 1:
+				return "LD A,(BC)"
+			case 2:
+				return "LD (DE),A"
+			case 3:
+				return "LD A,(DE)"
+			case 4:
+				return "LD (" + dec.nn() + ")," + dec.hl()
+			case 5:
+				return "LD " + dec.hl() + ",(" + dec.nn() + ")"
+			case 6:
+				return "LD (" + dec.nn() + "),A"
+			}
+			return "LD A,(" + dec.nn() + ")"
+		case 3:
+			if q == 0 {
+				return "INC " + dec.rp(p)
+			}
+			return "DEC " + dec.rp(p)
+		case 4:
+			return "INC " + dec.r(y)
+		case 5:
+			return "DEC " + dec.r(y)
+		case 6:
+			return "LD " + dec.r(y) + "," + dec.n()
+		}
+		return z80RotA[y]
+	case 1:
+		switch {
+		case y == 6 && z == 6:
+			return "HALT"
+		case y == 6:
+			return "LD " + dec.r(y) + "," + z80R[z]
+		case z == 6:
+			return "LD " + z80R[y] + "," + dec.r(z)
+		}
+		return "LD " + dec.r(y) + "," + dec.r(z)
+	case 2:
+		return z80ALU[y] + dec.r(z)
+	}
+
+	switch z {
+	case 0:
+		return "RET " + z80CC[y]
+	case 1:
+		if q == 0 {
+			return "POP " + dec.rp2(p)
+		}
+		return [...]string{"RET", "EXX", "JP (" + dec.hl() + ")", "LD SP," + dec.hl()}[p]
+	case 2:
+		return "JP " + z80CC[y] + "," + dec.nn()
+	case 3:
+		switch y {
+		case 0:
+			return "JP " + dec.nn()
+		case 2:
+			return "OUT (" + dec.n() + "),A"
+		case 3:
+			return "IN A,(" + dec.n() + ")"
+		case 4:
+			return "EX (SP)," + dec.hl()
+		case 5:
+			return "EX DE,HL"
+		case 6:
+			return "DI"
+		}
+		return "EI"
+	case 4:
+		return "CALL " + z80CC[y] + "," + dec.nn()
+	case 5:
+		if q == 0 {
+			return "PUSH " + dec.rp2(p)
+		}
+		return "CALL " + dec.nn()
+	case 6:
+		return z80ALU[y] + dec.n()
+	}
+	return fmt.Sprintf("RST %02Xh", y*8)
+}
+
+func (dec *z80Decoder) cb(opCode uint8) string {
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+
+	if x == 0 {
+		return z80Rot[y] + " " + z80R[z]
+	}
+	return fmt.Sprintf("%s %d,%s", [...]string{"", "BIT", "RES", "SET"}[x], y, z80R[z])
+}
+
+// indexedCB decodes DD CB d op and FD CB d op; undocumented forms with register other than (HL)
+// also copy the result to that register
+func (dec *z80Decoder) indexedCB() string {
+	operand := dec.r(6)
+	opCode := dec.next()
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+
+	var name string
+	if x == 0 {
+		name = z80Rot[y] + " " + operand
+	} else {
+		name = fmt.Sprintf("%s %d,%s", [...]string{"", "BIT", "RES", "SET"}[x], y, operand)
+	}
+
+	if z != 6 && x != 1 {
+		name += "," + z80R[z]
+	}
+	return name
+}
+
+func (dec *z80Decoder) ed(opCode uint8) string {
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+	p, q := y>>1, y&1
+
+	if x == 2 && y >= 4 && z <= 3 {
+		return z80Block[y-4][z]
+	}
+	if x != 1 {
+		return "*NOP"
+	}
+
+	switch z {
+	case 0:
+		if y == 6 {
+			return "IN (C)"
+		}
+		return "IN " + z80R[y] + ",(C)"
+	case 1:
+		if y == 6 {
+			return "OUT (C),0"
+		}
+		return "OUT (C)," + z80R[y]
+	case 2:
+		if q == 0 {
+			return "SBC HL," + z80RP[p]
+		}
+		return "ADC HL," + z80RP[p]
+	case 3:
+		if q == 0 {
+			return "LD (" + dec.nn() + ")," + z80RP[p]
+		}
+		return "LD " + z80RP[p] + ",(" + dec.nn() + ")"
+	case 4:
+		return "NEG"
+	case 5:
+		if y == 1 {
+			return "RETI"
+		}
+		return "RETN"
+	case 6:
+		return "IM " + z80IM[y]
+	}
+	return z80EDMisc[y]
+}
@@ -0,0 +1,130 @@
+package disassembler
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDecodeZ80(t *testing.T) {
+	testCases := []struct {
+		group string
+		data  []byte
+		name  string
+		args  []string
+	}{
+		{"unprefixed", []byte{0x00}, "NOP", nil},
+		{"unprefixed", []byte{0x08}, "EX AF,AF'", nil},
+		{"unprefixed", []byte{0x10, 0xfe}, "DJNZ e", []string{"fe"}},
+		{"unprefixed", []byte{0x20, 0x05}, "JR NZ,e", []string{"05"}},
+		{"unprefixed", []byte{0x21, 0x34, 0x12}, "LD HL,nn", []string{"1234"}},
+		{"unprefixed", []byte{0x22, 0x34, 0x12}, "LD (nn),HL", []string{"1234"}},
+		{"unprefixed", []byte{0x36, 0x1f}, "LD (HL),n", []string{"1f"}},
+		{"unprefixed", []byte{0x2f}, "CPL", nil},
+		{"unprefixed", []byte{0x76}, "HALT", nil},
+		{"unprefixed", []byte{0x7e}, "LD A,(HL)", nil},
+		{"unprefixed", []byte{0x9e}, "SBC A,(HL)", nil},
+		{"unprefixed", []byte{0xc1}, "POP BC", nil},
+		{"unprefixed", []byte{0xd9}, "EXX", nil},
+		{"unprefixed", []byte{0xdb, 0xfe}, "IN A,(n)", []string{"fe"}},
+		{"unprefixed", []byte{0xeb}, "EX DE,HL", nil},
+		{"unprefixed", []byte{0xf5}, "PUSH AF", nil},
+		{"unprefixed", []byte{0xfe, 0x0a}, "CP n", []string{"0a"}},
+		{"unprefixed", []byte{0xff}, "RST 38h", nil},
+
+		{"CB", []byte{0xcb, 0x00}, "RLC B", nil},
+		{"CB", []byte{0xcb, 0x36}, "SLL (HL)", nil},
+		{"CB", []byte{0xcb, 0x3f}, "SRL A", nil},
+		{"CB", []byte{0xcb, 0x7e}, "BIT 7,(HL)", nil},
+		{"CB", []byte{0xcb, 0x81}, "RES 0,C", nil},
+		{"CB", []byte{0xcb, 0xea}, "SET 5,D", nil},
+
+		{"ED", []byte{0xed, 0x40}, "IN B,(C)", nil},
+		{"ED", []byte{0xed, 0x70}, "IN (C)", nil},
+		{"ED", []byte{0xed, 0x71}, "OUT (C),0", nil},
+		{"ED", []byte{0xed, 0x42}, "SBC HL,BC", nil},
+		{"ED", []byte{0xed, 0x7a}, "ADC HL,SP", nil},
+		{"ED", []byte{0xed, 0x43, 0x34, 0x12}, "LD (nn),BC", []string{"1234"}},
+		{"ED", []byte{0xed, 0x7b, 0x34, 0x12}, "LD SP,(nn)", []string{"1234"}},
+		{"ED", []byte{0xed, 0x44}, "NEG", nil},
+		{"ED", []byte{0xed, 0x45}, "RETN", nil},
+		{"ED", []byte{0xed, 0x4d}, "RETI", nil},
+		{"ED", []byte{0xed, 0x5e}, "IM 2", nil},
+		{"ED", []byte{0xed, 0x4e}, "IM 0/1", nil},
+		{"ED", []byte{0xed, 0x57}, "LD A,I", nil},
+		{"ED", []byte{0xed, 0x6f}, "RLD", nil},
+		{"ED", []byte{0xed, 0xa0}, "LDI", nil},
+		{"ED", []byte{0xed, 0xb1}, "CPIR", nil},
+		{"ED", []byte{0xed, 0xbb}, "OTDR", nil},
+		{"ED", []byte{0xed, 0x00}, "*NOP", nil},
+		{"ED", []byte{0xed, 0x77}, "*NOP", nil},
+
+		{"DD", []byte{0xdd, 0x21, 0x34, 0x12}, "LD IX,nn", []string{"1234"}},
+		{"DD", []byte{0xdd, 0x29}, "ADD IX,IX", nil},
+		{"DD", []byte{0xdd, 0x2a, 0x34, 0x12}, "LD IX,(nn)", []string{"1234"}},
+		{"DD", []byte{0xdd, 0x34, 0x05}, "INC (IX+d)", []string{"05"}},
+		{"DD", []byte{0xdd, 0x36, 0xfb, 0x1f}, "LD (IX+d),n", []string{"fb", "1f"}},
+		{"DD", []byte{0xdd, 0x46, 0x05}, "LD B,(IX+d)", []string{"05"}},
+		{"DD", []byte{0xdd, 0x74, 0x05}, "LD (IX+d),H", []string{"05"}},
+		{"DD", []byte{0xdd, 0x66, 0x05}, "LD H,(IX+d)", []string{"05"}},
+		{"DD", []byte{0xdd, 0x86, 0x05}, "ADD A,(IX+d)", []string{"05"}},
+		{"DD", []byte{0xdd, 0x76}, "HALT", nil},
+		{"DD", []byte{0xdd, 0xe1}, "POP IX", nil},
+		{"DD", []byte{0xdd, 0xe3}, "EX (SP),IX", nil},
+		{"DD", []byte{0xdd, 0xe9}, "JP (IX)", nil},
+		{"DD", []byte{0xdd, 0xf9}, "LD SP,IX", nil},
+		{"DD", []byte{0xdd, 0xeb}, "EX DE,HL", nil},
+		{"DD", []byte{0xdd, 0x00}, "NOP", nil},
+		{"DD", []byte{0xdd, 0xdd, 0x00}, "*NOP", nil},
+		{"DD", []byte{0xdd, 0xed, 0x44}, "*NOP", nil},
+
+		{"DD undocumented", []byte{0xdd, 0x24}, "INC IXH", nil},
+		{"DD undocumented", []byte{0xdd, 0x2d}, "DEC IXL", nil},
+		{"DD undocumented", []byte{0xdd, 0x26, 0x1f}, "LD IXH,n", []string{"1f"}},
+		{"DD undocumented", []byte{0xdd, 0x44}, "LD B,IXH", nil},
+		{"DD undocumented", []byte{0xdd, 0x65}, "LD IXH,IXL", nil},
+		{"DD undocumented", []byte{0xdd, 0x6f}, "LD IXL,A", nil},
+		{"DD undocumented", []byte{0xdd, 0x84}, "ADD A,IXH", nil},
+		{"DD undocumented", []byte{0xdd, 0xbd}, "CP IXL", nil},
+
+		{"FD", []byte{0xfd, 0x21, 0x34, 0x12}, "LD IY,nn", []string{"1234"}},
+		{"FD", []byte{0xfd, 0x35, 0x80}, "DEC (IY+d)", []string{"80"}},
+		{"FD", []byte{0xfd, 0x77, 0x02}, "LD (IY+d),A", []string{"02"}},
+		{"FD", []byte{0xfd, 0xe5}, "PUSH IY", nil},
+		{"FD undocumented", []byte{0xfd, 0x6c}, "LD IYL,IYH", nil},
+		{"FD undocumented", []byte{0xfd, 0xa5}, "AND IYL", nil},
+
+		{"DD CB", []byte{0xdd, 0xcb, 0x05, 0x06}, "RLC (IX+d)", []string{"05"}},
+		{"DD CB", []byte{0xdd, 0xcb, 0x05, 0x46}, "BIT 0,(IX+d)", []string{"05"}},
+		{"DD CB", []byte{0xdd, 0xcb, 0x05, 0xbe}, "RES 7,(IX+d)", []string{"05"}},
+		{"DD CB", []byte{0xdd, 0xcb, 0x05, 0xde}, "SET 3,(IX+d)", []string{"05"}},
+		{"DD CB undocumented", []byte{0xdd, 0xcb, 0x05, 0x00}, "RLC (IX+d),B", []string{"05"}},
+		{"DD CB undocumented", []byte{0xdd, 0xcb, 0x05, 0x37}, "SLL (IX+d),A", []string{"05"}},
+		{"DD CB undocumented", []byte{0xdd, 0xcb, 0x05, 0x41}, "BIT 0,(IX+d)", []string{"05"}},
+		{"DD CB undocumented", []byte{0xdd, 0xcb, 0x05, 0xfc}, "SET 7,(IX+d),H", []string{"05"}},
+		{"FD CB", []byte{0xfd, 0xcb, 0xff, 0x1e}, "RR (IY+d)", []string{"ff"}},
+		{"FD CB undocumented", []byte{0xfd, 0xcb, 0xff, 0x8a}, "RES 1,(IY+d),D", []string{"ff"}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.group+" "+testCase.name, func(t *testing.T) {
+			inst, err := decodeZ80(testCase.data)
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.name, inst.name, "decodes mnemonic")
+			assert.Equal(t, testCase.args, inst.args, "reads args in order of the mnemonic")
+
+			size := len(testCase.data)
+			if testCase.name == "*NOP" && testCase.data[0] != 0xed {
+				size = 1 // prefix followed by another one is skipped on its own
+			}
+			assert.Equal(t, size, inst.size, "takes instruction bytes")
+		})
+	}
+
+	t.Run("when instruction is cut off", func(t *testing.T) {
+		for _, data := range [][]byte{{0x21, 0x34}, {0xdd, 0x36, 0x05}, {0xfd, 0xcb, 0x05}, {0xed}} {
+			_, err := decodeZ80(data)
+			assert.EqualError(t, err, "instruction is cut off")
+		}
+	})
+}
@@ -0,0 +1,213 @@
+package eighty_eighty
+
+import (
+	"fmt"
+	"io"
+)
+
+// openBus is returned when reading addresses with nothing attached
+const openBus = 0xff
+
+// Bus is a memory bus; CPU goes through it for every memory access
+type Bus interface {
+	Read(address uint16) uint8
+	Write(address uint16, val uint8)
+}
+
+// RAM is a flat read/write memory
+type RAM []uint8
+
+// NewRAM returns zeroed RAM of provided size
+func NewRAM(size int) RAM {
+	return make(RAM, size)
+}
+
+// Read returns value stored at provided address
+func (r RAM) Read(address uint16) uint8 {
+	if int(address) >= len(r) {
+		return openBus
+	}
+
+	return r[address]
+}
+
+// Write stores value at provided address
+func (r RAM) Write(address uint16, val uint8) {
+	if int(address) < len(r) {
+		r[address] = val
+	}
+}
+
+// Snapshot writes RAM contents
+func (r RAM) Snapshot(w io.Writer) error {
+	_, err := w.Write(r)
+	return err
+}
+
+// Restore reads RAM contents saved with Snapshot
+func (r RAM) Restore(rd io.Reader) error {
+	_, err := io.ReadFull(rd, r)
+	return err
+}
+
+// ROM is a read only memory; writes are ignored
+type ROM []uint8
+
+// Read returns value stored at provided address
+func (r ROM) Read(address uint16) uint8 {
+	if int(address) >= len(r) {
+		return openBus
+	}
+
+	return r[address]
+}
+
+// Write does nothing as ROM can't be written to
+func (r ROM) Write(address uint16, val uint8) {}
+
+// Callback is a memory backed by provided functions, e.g. memory mapped devices;
+// missing ReadFunc reads open bus, missing WriteFunc ignores writes
+type Callback struct {
+	ReadFunc  func(address uint16) uint8
+	WriteFunc func(address uint16, val uint8)
+}
+
+// Read calls ReadFunc with provided address
+func (cb Callback) Read(address uint16) uint8 {
+	if cb.ReadFunc == nil {
+		return openBus
+	}
+
+	return cb.ReadFunc(address)
+}
+
+// Write calls WriteFunc with provided address and value
+func (cb Callback) Write(address uint16, val uint8) {
+	if cb.WriteFunc != nil {
+		cb.WriteFunc(address, val)
+	}
+}
+
+type mirror struct {
+	bus  Bus
+	size uint32
+}
+
+// Mirror returns a bus which repeats provided one every size bytes; size 0 stands for the whole
+// 64K address space, which uint16 can't hold, so the bus isn't repeated at all
+func Mirror(bus Bus, size uint16) Bus {
+	mr := &mirror{bus: bus, size: uint32(size)}
+	if size == 0 {
+		mr.size = 0x10000
+	}
+	return mr
+}
+
+func (mr *mirror) Read(address uint16) uint8 {
+	return mr.bus.Read(uint16(uint32(address) % mr.size))
+}
+
+func (mr *mirror) Write(address uint16, val uint8) {
+	mr.bus.Write(uint16(uint32(address)%mr.size), val)
+}
+
+func (mr *mirror) Snapshot(w io.Writer) error {
+	if sn, ok := mr.bus.(Snapshotter); ok {
+		return sn.Snapshot(w)
+	}
+	return nil
+}
+
+func (mr *mirror) Restore(r io.Reader) error {
+	if sn, ok := mr.bus.(Snapshotter); ok {
+		return sn.Restore(r)
+	}
+	return nil
+}
+
+type region struct {
+	start uint16
+	end   uint16
+	bus   Bus
+}
+
+// Map is a bus assembled from regions attached to address ranges; regions see addresses relative
+// to their start and accessing unmapped addresses reads open bus and ignores writes
+type Map struct {
+	regions []region
+}
+
+// NewMap returns empty memory map
+func NewMap() *Map {
+	return &Map{}
+}
+
+// Attach maps provided bus to addresses from start to end inclusive
+func (mp *Map) Attach(start, end uint16, bus Bus) error {
+	if end < start {
+		return fmt.Errorf("region end %#04x is before its start %#04x", end, start)
+	}
+
+	for _, r := range mp.regions {
+		if start <= r.end && r.start <= end {
+			return fmt.Errorf("region %#04x-%#04x overlaps %#04x-%#04x", start, end, r.start, r.end)
+		}
+	}
+
+	mp.regions = append(mp.regions, region{start: start, end: end, bus: bus})
+	return nil
+}
+
+// Read returns value from region mapped at provided address
+func (mp *Map) Read(address uint16) uint8 {
+	r := mp.find(address)
+	if r == nil {
+		return openBus
+	}
+
+	return r.bus.Read(address - r.start)
+}
+
+// Write stores value in region mapped at provided address
+func (mp *Map) Write(address uint16, val uint8) {
+	r := mp.find(address)
+	if r != nil {
+		r.bus.Write(address-r.start, val)
+	}
+}
+
+// Snapshot writes state of regions implementing Snapshotter; the rest, like ROM, is skipped
+func (mp *Map) Snapshot(w io.Writer) error {
+	for _, r := range mp.regions {
+		if sn, ok := r.bus.(Snapshotter); ok {
+			if err := writeSection(w, sn); err != nil {
+				return fmt.Errorf("region %#04x-%#04x: %s", r.start, r.end, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+// Restore reads state of regions saved with Snapshot
+func (mp *Map) Restore(rd io.Reader) error {
+	for _, r := range mp.regions {
+		if sn, ok := r.bus.(Snapshotter); ok {
+			if err := readSection(rd, sn); err != nil {
+				return fmt.Errorf("region %#04x-%#04x: %s", r.start, r.end, err.Error())
+			}
+		}
+	}
+
+	return nil
+}
+
+func (mp *Map) find(address uint16) *region {
+	for i := range mp.regions {
+		if address >= mp.regions[i].start && address <= mp.regions[i].end {
+			return &mp.regions[i]
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,105 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRAM(t *testing.T) {
+	ram := NewRAM(0x10)
+
+	ram.Write(0x0f, 0x1f)
+	assert.Equal(t, uint8(0x1f), ram.Read(0x0f), "stores written value")
+
+	ram.Write(0x10, 0x1f)
+	assert.Equal(t, uint8(openBus), ram.Read(0x10), "reads open bus outside of its size")
+}
+
+func TestROM(t *testing.T) {
+	rom := ROM{0x3e, 0x1f}
+
+	rom.Write(0x00, 0xaa)
+	assert.Equal(t, uint8(0x3e), rom.Read(0x00), "ignores writes")
+	assert.Equal(t, uint8(openBus), rom.Read(0x02), "reads open bus outside of its size")
+}
+
+func TestCallback(t *testing.T) {
+	t.Run("with functions", func(t *testing.T) {
+		var written []uint16
+		cb := Callback{
+			ReadFunc:  func(address uint16) uint8 { return uint8(address) },
+			WriteFunc: func(address uint16, val uint8) { written = append(written, address, uint16(val)) },
+		}
+
+		cb.Write(0x10, 0x20)
+		assert.Equal(t, uint8(0x42), cb.Read(0x42), "reads through ReadFunc")
+		assert.Equal(t, []uint16{0x10, 0x20}, written, "writes through WriteFunc")
+	})
+
+	t.Run("without functions", func(t *testing.T) {
+		cb := Callback{}
+
+		cb.Write(0x10, 0x20)
+		assert.Equal(t, uint8(openBus), cb.Read(0x10), "reads open bus")
+	})
+}
+
+func TestMirror(t *testing.T) {
+	t.Run("mirroring bus", func(t *testing.T) {
+		ram := NewRAM(0x100)
+		mirrored := Mirror(ram, 0x100)
+
+		mirrored.Write(0x0342, 0x1f)
+		assert.Equal(t, uint8(0x1f), ram.Read(0x42), "writes underlying bus modulo size")
+		assert.Equal(t, uint8(0x1f), mirrored.Read(0x0142), "reads underlying bus modulo size")
+	})
+
+	t.Run("when size is 0", func(t *testing.T) {
+		ram := NewRAM(0x10000)
+		mirrored := Mirror(ram, 0)
+
+		mirrored.Write(0xff42, 0x1f)
+		assert.Equal(t, uint8(0x1f), ram.Read(0xff42), "mirrors the whole 64K")
+		assert.Equal(t, uint8(0x1f), mirrored.Read(0xff42), "reads address as is")
+		assert.Equal(t, uint8(0x00), mirrored.Read(0x0042), "does not repeat the bus")
+	})
+}
+
+func TestMap(t *testing.T) {
+	t.Run("accessing regions", func(t *testing.T) {
+		rom := ROM{0x01, 0x02}
+		ram := NewRAM(0x10)
+		mp := NewMap()
+		assert.Nil(t, mp.Attach(0x0000, 0x0001, rom))
+		assert.Nil(t, mp.Attach(0x2000, 0x200f, ram))
+
+		mp.Write(0x2001, 0x1f)
+		mp.Write(0x0000, 0xff)
+		assert.Equal(t, uint8(0x1f), ram.Read(0x01), "passes address relative to region start")
+		assert.Equal(t, uint8(0x1f), mp.Read(0x2001), "reads from mapped region")
+		assert.Equal(t, uint8(0x01), mp.Read(0x0000), "keeps region semantics")
+		assert.Equal(t, uint8(openBus), mp.Read(0x1000), "reads open bus from unmapped address")
+	})
+
+	t.Run("attaching overlapping regions", func(t *testing.T) {
+		mp := NewMap()
+		assert.Nil(t, mp.Attach(0x2000, 0x3fff, NewRAM(0x2000)))
+
+		assert.NotNil(t, mp.Attach(0x3000, 0x4fff, NewRAM(0x2000)), "rejects overlapping region")
+		assert.NotNil(t, mp.Attach(0x5000, 0x4fff, NewRAM(0x2000)), "rejects inverted region")
+	})
+
+	t.Run("running CPU on it", func(t *testing.T) {
+		mp := NewMap()
+		mp.Attach(0x0000, 0x00ff, ROM{0x3e, 0x1f, 0x32, 0x00, 0x20}) // MVI A,0x1f; STA 0x2000
+		mp.Attach(0x2000, 0x20ff, NewRAM(0x100))
+		cpu := New(WithBus(mp))
+
+		_, err := cpu.Step()
+		assert.Nil(t, err)
+		_, err = cpu.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x1f), mp.Read(0x2000), "executes program from ROM")
+	})
+}
@@ -1,49 +1,58 @@
 package eighty_eighty
 
 import (
-	"strconv"
+	"math/bits"
 )
 
 type condCodes struct {
-	z   uint8 // zero flag -> if result of operation is equal to 0 it's set to 1
-	s   uint8 // sign flag -> set to 1 when bit 7 is set
-	p   uint8 // parity flag -> check if results 1 bits count is even or odd
-	cy  uint8 // carry bit flag -> check if result requires carrying a bit of a higher order
-	ac  uint8 // auxiliary carry bit; leaving it for now
-	pad uint8
+	z  uint8 // zero flag -> if result of operation is equal to 0 it's set to 1
+	s  uint8 // sign flag -> set to 1 when bit 7 is set
+	p  uint8 // parity flag -> check if results 1 bits count is even or odd
+	cy uint8 // carry bit flag -> check if result requires carrying a bit of a higher order
+	ac uint8 // auxiliary carry bit -> check if result requires carrying a bit out of bit 3
 }
 
-func (c *condCodes) setZ(result uint16) {
-	if result&0xff == 0 {
-		c.z = 1
-	} else {
-		c.z = 0
+// flag byte layout: S Z 0 AC 0 P 1 CY
+const (
+	cyBit = 0
+	pBit  = 2
+	acBit = 4
+	zBit  = 6
+	sBit  = 7
+
+	fixedFlagBits = 1 << 1 // bit 1 always reads as one, bits 3 and 5 as zero
+)
+
+// zero, sign and parity flags of every 8bit result, computed once so setting them is a single lookup
+var (
+	zeroTable   [256]uint8
+	signTable   [256]uint8
+	parityTable [256]uint8
+)
+
+func init() {
+	for result := 0; result < 256; result++ {
+		if result == 0 {
+			zeroTable[result] = 1
+		}
+		signTable[result] = uint8(result) >> 7
+		parityTable[result] = uint8(1 - bits.OnesCount8(uint8(result))%2)
 	}
 }
 
-//  TODO: make sure it's not inverted
+// setZ sets zero flag when low byte of the result is equal to zero
+func (c *condCodes) setZ(result uint16) {
+	c.z = zeroTable[uint8(result)]
+}
+
+// setS sets sign flag to bit 7 of the result
 func (c *condCodes) setS(result uint16) {
-	if result&(1<<7) == 0 {
-		c.s = 0
-	} else {
-		c.s = 1
-	}
+	c.s = signTable[uint8(result)]
 }
 
+// setP sets parity flag when low byte of the result has even number of ones
 func (c *condCodes) setP(result uint16) {
-	var ones int
-
-	for _, char := range strconv.FormatInt(int64(result), 2) {
-		if string(char) == "1" {
-			ones++
-		}
-	}
-
-	if ones%2 == 0 {
-		c.p = 1
-	} else {
-		c.p = 0
-	}
+	c.p = parityTable[uint8(result)]
 }
 
 func (c *condCodes) setCY(result uint16) {
@@ -53,3 +62,45 @@ func (c *condCodes) setCY(result uint16) {
 		c.cy = 0
 	}
 }
+
+// setZSP sets zero, sign and parity flags basing on the provided result
+func (c *condCodes) setZSP(result uint16) {
+	low := uint8(result)
+	c.z = zeroTable[low]
+	c.s = signTable[low]
+	c.p = parityTable[low]
+}
+
+// setACAdd sets auxiliary carry flag when adding provided values and carry carries a bit out of bit 3
+func (c *condCodes) setACAdd(a, b, carry uint8) {
+	if (a&0x0f)+(b&0x0f)+carry > 0x0f {
+		c.ac = 1
+	} else {
+		c.ac = 0
+	}
+}
+
+// setACSub sets auxiliary carry flag for subtraction; 8080 subtracts by adding two's complement
+// of subtrahend, so the flag is set when there's no borrow into bit 4
+func (c *condCodes) setACSub(a, b, borrow uint8) {
+	c.setACAdd(a, ^b, 1-borrow)
+}
+
+// setACAnd sets auxiliary carry flag for logical and; 8080 sets it to logical or of bit 3 of operands
+func (c *condCodes) setACAnd(a, b uint8) {
+	c.ac = ((a | b) >> 3) & 1
+}
+
+// pack returns condition codes as the flag byte pushed on the stack by PUSH PSW
+func (c *condCodes) pack() uint8 {
+	return c.s<<sBit | c.z<<zBit | c.ac<<acBit | c.p<<pBit | c.cy<<cyBit | fixedFlagBits
+}
+
+// unpack sets condition codes from the flag byte popped from the stack by POP PSW; fixed bits are ignored
+func (c *condCodes) unpack(flags uint8) {
+	c.s = flags >> sBit & 1
+	c.z = flags >> zBit & 1
+	c.ac = flags >> acBit & 1
+	c.p = flags >> pBit & 1
+	c.cy = flags >> cyBit & 1
+}
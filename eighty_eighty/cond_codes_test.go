@@ -91,3 +91,146 @@ func TestCarryBitFlagSetting(t *testing.T) {
 		assert.Zero(t, cc.cy, "sets zero flag to zero")
 	})
 }
+
+func TestAuxiliaryCarryFlagSetting(t *testing.T) {
+	cc := &condCodes{}
+
+	t.Run("when addition carries out of bit 3", func(t *testing.T) {
+		cc.setACAdd(0x0f, 0x01, 0)
+
+		assert.Equal(t, uint8(1), cc.ac, "sets auxiliary carry flag to one")
+	})
+
+	t.Run("when addition carries out of bit 3 because of carry", func(t *testing.T) {
+		cc.ac = 0
+		cc.setACAdd(0x0e, 0x01, 1)
+
+		assert.Equal(t, uint8(1), cc.ac, "sets auxiliary carry flag to one")
+	})
+
+	t.Run("when addition does not carry out of bit 3", func(t *testing.T) {
+		cc.ac = 1
+		cc.setACAdd(0xf7, 0x08, 0)
+
+		assert.Zero(t, cc.ac, "sets auxiliary carry flag to zero")
+	})
+
+	t.Run("when subtraction does not borrow into bit 4", func(t *testing.T) {
+		cc.ac = 0
+		cc.setACSub(0x05, 0x03, 0)
+
+		assert.Equal(t, uint8(1), cc.ac, "sets auxiliary carry flag to one")
+	})
+
+	t.Run("when subtraction borrows into bit 4", func(t *testing.T) {
+		cc.ac = 1
+		cc.setACSub(0x10, 0x01, 0)
+
+		assert.Zero(t, cc.ac, "sets auxiliary carry flag to zero")
+	})
+
+	t.Run("when subtraction borrows into bit 4 because of borrow", func(t *testing.T) {
+		cc.ac = 1
+		cc.setACSub(0x05, 0x05, 1)
+
+		assert.Zero(t, cc.ac, "sets auxiliary carry flag to zero")
+	})
+
+	t.Run("when bit 3 of any and operand is set", func(t *testing.T) {
+		cc.ac = 0
+		cc.setACAnd(0x08, 0x00)
+
+		assert.Equal(t, uint8(1), cc.ac, "sets auxiliary carry flag to one")
+	})
+
+	t.Run("when bit 3 of neither and operand is set", func(t *testing.T) {
+		cc.ac = 1
+		cc.setACAnd(0xf7, 0xf7)
+
+		assert.Zero(t, cc.ac, "sets auxiliary carry flag to zero")
+	})
+}
+
+func TestFlagBytePacking(t *testing.T) {
+	t.Run("packing", func(t *testing.T) {
+		cc := &condCodes{s: 1, z: 1, ac: 1, p: 1, cy: 1}
+		assert.Equal(t, uint8(0xd7), cc.pack(), "places flags at S Z 0 AC 0 P 1 CY positions")
+
+		cc = &condCodes{}
+		assert.Equal(t, uint8(0x02), cc.pack(), "always sets bit 1")
+	})
+
+	t.Run("unpacking", func(t *testing.T) {
+		cc := &condCodes{}
+		cc.unpack(0xff)
+		assert.Equal(t, condCodes{s: 1, z: 1, ac: 1, p: 1, cy: 1}, *cc, "reads flags ignoring fixed bits")
+
+		cc.unpack(0x28)
+		assert.Equal(t, condCodes{}, *cc, "ignores bits 3 and 5")
+	})
+
+	t.Run("round trip", func(t *testing.T) {
+		cc := &condCodes{}
+
+		for flags := 0; flags < 256; flags++ {
+			cc.unpack(uint8(flags))
+			assert.Equal(t, uint8(flags)&0xd5|0x02, cc.pack(), "preserves flags and normalizes fixed bits")
+		}
+	})
+}
+
+func TestFlagTables(t *testing.T) {
+	cc := &condCodes{}
+
+	t.Run("when bits above 7th are set", func(t *testing.T) {
+		cc.setZSP(0x0100)
+		assert.Equal(t, condCodes{z: 1, p: 1}, *cc, "uses only low byte of the result")
+
+		cc.setZSP(0x01fe)
+		assert.Equal(t, condCodes{s: 1}, *cc, "uses only low byte of the result")
+	})
+
+	t.Run("matching bit by bit computation", func(t *testing.T) {
+		for result := 0; result < 256; result++ {
+			cc.setZSP(uint16(result))
+			assert.Equal(t, setPStrconv(uint16(result)), cc.p, "sets parity flag of %#02x", result)
+		}
+	})
+}
+
+// setPStrconv computes parity flag by counting ones in the binary representation of the result
+func setPStrconv(result uint16) uint8 {
+	var ones int
+
+	for _, char := range strconv.FormatInt(int64(result&0xff), 2) {
+		if string(char) == "1" {
+			ones++
+		}
+	}
+
+	if ones%2 == 0 {
+		return 1
+	}
+	return 0
+}
+
+func BenchmarkParityStrconv(b *testing.B) {
+	var p uint8
+	for i := 0; i < b.N; i++ {
+		p ^= setPStrconv(uint16(i))
+	}
+}
+
+func BenchmarkParityTable(b *testing.B) {
+	cc := &condCodes{}
+	for i := 0; i < b.N; i++ {
+		cc.setP(uint16(i))
+	}
+}
+
+func BenchmarkSetZSP(b *testing.B) {
+	cc := &condCodes{}
+	for i := 0; i < b.N; i++ {
+		cc.setZSP(uint16(i))
+	}
+}
@@ -0,0 +1,192 @@
+package eighty_eighty
+
+import (
+	"fmt"
+)
+
+// Flags represents 8080 condition codes
+type Flags struct {
+	Z  bool // zero
+	S  bool // sign
+	P  bool // parity
+	CY bool // carry
+	AC bool // auxiliary carry
+}
+
+// Step serves pending interrupt if CPU accepts it, otherwise it executes single instruction.
+// Halted CPU doesn't fetch instructions and only lets haltedCycles pass until interrupted.
+// It returns number of cycles spent.
+func (s *CPU) Step() (int, error) {
+	if s.replaying() {
+		if err := s.replayEvents(); err != nil {
+			return 0, err
+		}
+	}
+
+	if s.intDelay {
+		s.intDelay = false
+	} else if s.interruptPending() {
+		return s.serveInterrupt()
+	}
+
+	if s.halted {
+		s.cycles += haltedCycles
+		return haltedCycles, nil
+	}
+
+	return s.Emulate()
+}
+
+// Run executes instructions until CPU halts with no interrupt to wake it up or one of them fails
+func (s *CPU) Run() error {
+	for {
+		if _, err := s.Step(); err != nil {
+			return err
+		}
+		if s.Idle() {
+			return nil
+		}
+	}
+}
+
+// Halted reports whether CPU executed HLT and waits for an interrupt
+func (s *CPU) Halted() bool { return s.halted }
+
+// Idle reports whether CPU is halted with no interrupt to wake it up
+func (s *CPU) Idle() bool { return s.halted && !s.interruptPending() }
+
+// A returns accumulator value
+func (s *CPU) A() uint8 { return s.a }
+
+// B returns value of register b
+func (s *CPU) B() uint8 { return s.b }
+
+// C returns value of register c
+func (s *CPU) C() uint8 { return s.c }
+
+// D returns value of register d
+func (s *CPU) D() uint8 { return s.d }
+
+// E returns value of register e
+func (s *CPU) E() uint8 { return s.e }
+
+// H returns value of register h
+func (s *CPU) H() uint8 { return s.h }
+
+// L returns value of register l
+func (s *CPU) L() uint8 { return s.l }
+
+// SetA sets accumulator value
+func (s *CPU) SetA(val uint8) { s.a = val }
+
+// SetB sets value of register b
+func (s *CPU) SetB(val uint8) { s.b = val }
+
+// SetC sets value of register c
+func (s *CPU) SetC(val uint8) { s.c = val }
+
+// SetD sets value of register d
+func (s *CPU) SetD(val uint8) { s.d = val }
+
+// SetE sets value of register e
+func (s *CPU) SetE(val uint8) { s.e = val }
+
+// SetH sets value of register h
+func (s *CPU) SetH(val uint8) { s.h = val }
+
+// SetL sets value of register l
+func (s *CPU) SetL(val uint8) { s.l = val }
+
+// BC returns value of bc registers pair
+func (s *CPU) BC() uint16 { return s.pair(bc) }
+
+// DE returns value of de registers pair
+func (s *CPU) DE() uint16 { return s.pair(de) }
+
+// HL returns value of hl registers pair
+func (s *CPU) HL() uint16 { return s.pair(hl) }
+
+// SP returns stack pointer
+func (s *CPU) SP() uint16 { return s.pair(sp) }
+
+// PSW returns accumulator and condition codes as a single 16bit word
+func (s *CPU) PSW() uint16 { return s.pair(psw) }
+
+// SetBC sets value of bc registers pair
+func (s *CPU) SetBC(val uint16) { s.setPair(bc, val) }
+
+// SetDE sets value of de registers pair
+func (s *CPU) SetDE(val uint16) { s.setPair(de, val) }
+
+// SetHL sets value of hl registers pair
+func (s *CPU) SetHL(val uint16) { s.setPair(hl, val) }
+
+// SetSP sets stack pointer
+func (s *CPU) SetSP(val uint16) { s.setPair(sp, val) }
+
+// SetPSW sets accumulator and condition codes from a single 16bit word
+func (s *CPU) SetPSW(val uint16) { s.setPair(psw, val) }
+
+// Cycles returns number of clock cycles executed since CPU creation
+func (s *CPU) Cycles() uint64 { return s.cycles }
+
+// PC returns program counter
+func (s *CPU) PC() uint16 { return s.pc }
+
+// SetPC sets program counter
+func (s *CPU) SetPC(val uint16) { s.pc = val }
+
+// Flags returns current condition codes
+func (s *CPU) Flags() Flags {
+	return Flags{
+		Z:  s.cc.z == 1,
+		S:  s.cc.s == 1,
+		P:  s.cc.p == 1,
+		CY: s.cc.cy == 1,
+		AC: s.cc.ac == 1,
+	}
+}
+
+// SetFlags sets condition codes
+func (s *CPU) SetFlags(f Flags) {
+	s.cc.z = bit(f.Z)
+	s.cc.s = bit(f.S)
+	s.cc.p = bit(f.P)
+	s.cc.cy = bit(f.CY)
+	s.cc.ac = bit(f.AC)
+}
+
+// Load writes provided data to memory bus starting at provided address
+func (s *CPU) Load(address uint16, data []byte) error {
+	if int(address)+len(data) > 0x10000 {
+		return fmt.Errorf("%d bytes don't fit in memory at %#04x", len(data), address)
+	}
+
+	for i, val := range data {
+		s.mem.Write(address+uint16(i), val)
+	}
+	return nil
+}
+
+// ReadMemory returns value stored at provided address
+func (s *CPU) ReadMemory(address uint16) uint8 {
+	return s.mem.Read(address)
+}
+
+// WriteMemory stores value at provided address
+func (s *CPU) WriteMemory(address uint16, val uint8) {
+	s.mem.Write(address, val)
+}
+
+// Bus returns memory bus CPU is attached to
+func (s *CPU) Bus() Bus {
+	return s.mem
+}
+
+func bit(val bool) uint8 {
+	if val {
+		return 1
+	}
+
+	return 0
+}
@@ -0,0 +1,112 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegisterAccessors(t *testing.T) {
+	cpu := New()
+	cpu.SetA(0x01)
+	cpu.SetB(0x02)
+	cpu.SetC(0x03)
+	cpu.SetD(0x04)
+	cpu.SetE(0x05)
+	cpu.SetH(0x06)
+	cpu.SetL(0x07)
+
+	assert.Equal(t, []uint8{0x01, 0x02, 0x03, 0x04, 0x05, 0x06, 0x07},
+		[]uint8{cpu.A(), cpu.B(), cpu.C(), cpu.D(), cpu.E(), cpu.H(), cpu.L()}, "reads registers back")
+	assert.Equal(t, uint16(0x0203), cpu.BC(), "reads bc pair")
+	assert.Equal(t, uint16(0x0405), cpu.DE(), "reads de pair")
+	assert.Equal(t, uint16(0x0607), cpu.HL(), "reads hl pair")
+
+	cpu.SetHL(0xbeef)
+	assert.Equal(t, uint8(0xbe), cpu.H(), "sets h from hl pair")
+	assert.Equal(t, uint8(0xef), cpu.L(), "sets l from hl pair")
+
+	cpu.SetSP(0x2400)
+	cpu.SetPC(0x0100)
+	assert.Equal(t, uint16(0x2400), cpu.SP(), "reads stack pointer")
+	assert.Equal(t, uint16(0x0100), cpu.PC(), "reads program counter")
+}
+
+func TestFlagsAccessors(t *testing.T) {
+	cpu := New()
+	flags := Flags{Z: true, CY: true}
+
+	cpu.SetFlags(flags)
+	assert.Equal(t, flags, cpu.Flags(), "reads flags back")
+	assert.Equal(t, condCodes{z: 1, cy: 1}, *cpu.cc, "sets condition codes")
+}
+
+func TestMemoryAccessors(t *testing.T) {
+	t.Run("loading data", func(t *testing.T) {
+		cpu := New()
+
+		err := cpu.Load(0x0100, []byte{0x3e, 0x1f})
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x3e), cpu.ReadMemory(0x0100), "copies first byte")
+		assert.Equal(t, uint8(0x1f), cpu.ReadMemory(0x0101), "copies second byte")
+	})
+
+	t.Run("when data does not fit", func(t *testing.T) {
+		cpu := New()
+
+		err := cpu.Load(0xffff, []byte{0x00, 0x00})
+		assert.NotNil(t, err)
+	})
+
+	t.Run("writing memory", func(t *testing.T) {
+		cpu := New()
+
+		cpu.WriteMemory(0x2000, 0xaa)
+		assert.Equal(t, uint8(0xaa), cpu.ReadMemory(0x2000), "stores value at address")
+	})
+}
+
+func TestStepAndRun(t *testing.T) {
+	t.Run("stepping", func(t *testing.T) {
+		cpu := New()
+		cpu.Load(0, []byte{0x3e, 0x1f}) // MVI A,0x1f
+
+		_, err := cpu.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x1f), cpu.A(), "executes instruction")
+		assert.Equal(t, uint16(2), cpu.PC(), "moves to the next instruction")
+	})
+
+	t.Run("running until failure", func(t *testing.T) {
+		cpu := New(WithUndocumented(TrapUndocumented))
+		cpu.Load(0, []byte{0x3c, 0x3c, 0x08}) // INR A; INR A; undocumented NOP
+
+		err := cpu.Run()
+		assert.NotNil(t, err)
+		assert.Equal(t, uint8(2), cpu.A(), "executes instructions before the failing one")
+	})
+
+	t.Run("running until halt", func(t *testing.T) {
+		cpu := New()
+		cpu.Load(0, []byte{0x3c, 0x3c, 0x76, 0x3c}) // INR A; INR A; HLT; INR A
+
+		err := cpu.Run()
+		assert.Nil(t, err)
+		assert.True(t, cpu.Halted(), "reports halted state")
+		assert.Equal(t, uint8(2), cpu.A(), "stops fetching after HLT")
+		assert.Equal(t, uint16(3), cpu.PC(), "points after HLT")
+	})
+
+	t.Run("running halted CPU with pending interrupt", func(t *testing.T) {
+		cpu := New()
+		cpu.SetSP(0x2400)
+		cpu.Load(0, []byte{0xfb, 0x76})      // EI; HLT
+		cpu.Load(0x0008, []byte{0x3c, 0x76}) // INR A; HLT
+		cpu.Interrupt(RST(1))
+
+		err := cpu.Run()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(1), cpu.A(), "resumes on interrupt")
+		assert.Equal(t, uint16(0x000a), cpu.PC(), "stops at the next HLT")
+	})
+}
@@ -0,0 +1,20 @@
+package eighty_eighty
+
+import (
+	"github.com/piokaczm/8080-emulator/spec"
+)
+
+// haltedCycles pass on every step of halted CPU
+const haltedCycles = 4
+
+// vectoredCycles pass while 8085 acknowledges TRAP or one of RST n.5 interrupts
+const vectoredCycles = 12
+
+// cycles returns number of clock cycles (T-states) opcode took, which for conditional instructions
+// depends on whether the condition was met
+func cycles(op *spec.Opcode, taken bool) int {
+	if taken {
+		return op.CyclesTaken
+	}
+	return op.Cycles
+}
@@ -0,0 +1,65 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCycles(t *testing.T) {
+	testCases := []struct {
+		name     string
+		program  []byte
+		z        uint8
+		expected int
+	}{
+		{"NOP", []byte{0x00}, 0, 4},
+		{"MOV B,C", []byte{0x41}, 0, 5},
+		{"MOV B,M", []byte{0x46}, 0, 7},
+		{"INR M", []byte{0x34}, 0, 10},
+		{"LHLD adr", []byte{0x2a, 0x00, 0x00}, 0, 16},
+		{"JZ adr when not taken", []byte{0xca, 0x00, 0x20}, 0, 10},
+		{"JZ adr when taken", []byte{0xca, 0x00, 0x20}, 1, 10},
+		{"CZ adr when not taken", []byte{0xcc, 0x00, 0x20}, 0, 11},
+		{"CZ adr when taken", []byte{0xcc, 0x00, 0x20}, 1, 17},
+		{"RZ when not taken", []byte{0xc8}, 0, 5},
+		{"RZ when taken", []byte{0xc8}, 1, 11},
+		{"XTHL", []byte{0xe3}, 0, 18},
+		{"RST 1", []byte{0xcf}, 0, 11},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ee := New()
+			ee.sp = 0x2400
+			ee.cc.z = testCase.z
+			ee.Load(0, testCase.program)
+
+			spent, err := ee.Emulate()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, spent, "returns number of cycles")
+		})
+	}
+
+	t.Run("counting cycles", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0x00, 0x3e, 0x01, 0x2a, 0x00, 0x00}) // NOP; MVI A,1; LHLD 0
+
+		for i := 0; i < 3; i++ {
+			_, err := ee.Step()
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, uint64(4+7+16), ee.Cycles(), "sums cycles of executed instructions")
+	})
+
+	t.Run("serving interrupt", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2400
+		ee.int_enable = 1
+
+		ee.Interrupt(RST(1))
+		spent, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, 11, spent, "takes cycles of instruction from the data bus")
+	})
+}
@@ -0,0 +1,56 @@
+package eighty_eighty
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/piokaczm/8080-emulator/internal/cpmtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// exerciser takes a bit over 23 billion cycles, so this only catches runaway programs
+const diagCycleLimit = 50000000000
+
+// TestDiagnostics runs classic 8080 diagnostic programs placed in testdata; they aren't distributed
+// with the repository, see testdata/README.md for fetching them
+func TestDiagnostics(t *testing.T) {
+	testCases := []struct {
+		file     string
+		expected string
+		long     bool
+	}{
+		{"TST8080.COM", "CPU IS OPERATIONAL", false},
+		{"CPUTEST.COM", "CPU TESTS OK", false},
+		{"8080PRE.COM", "8080 Preliminary tests complete", false},
+		{"8080EXM.COM", "Tests complete", true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.file, func(t *testing.T) {
+			program := cpmtest.Program(t, filepath.Join("testdata", testCase.file))
+			if testCase.long && testing.Short() {
+				t.Skipf("%s takes minutes to run", testCase.file)
+			}
+
+			output := cpmtest.Run(t, New(), program, diagCycleLimit)
+			t.Log(output)
+			assert.Contains(t, output, testCase.expected, "reports success")
+			assert.NotContains(t, output, "ERROR", "reports no errors")
+		})
+	}
+}
+
+func TestRunCPM(t *testing.T) {
+	program := []byte{
+		0x11, 0x12, 0x01, // LXI D,message
+		0x0e, 0x09, // MVI C,9
+		0xcd, 0x05, 0x00, // CALL BDOS
+		0x0e, 0x02, // MVI C,2
+		0x1e, '!', // MVI E,'!'
+		0xcd, 0x05, 0x00, // CALL BDOS
+		0xc3, 0x00, 0x00, // JMP 0
+		'O', 'K', '$', // message
+	}
+
+	assert.Equal(t, "OK!", cpmtest.Run(t, New(), program, diagCycleLimit), "captures console output until warm boot")
+}
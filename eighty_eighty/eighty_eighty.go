@@ -2,6 +2,8 @@ package eighty_eighty
 
 import (
 	"fmt"
+
+	"github.com/piokaczm/8080-emulator/spec"
 )
 
 const (
@@ -13,13 +15,18 @@ const (
 	e
 	h
 	l
+	m // memory cell addressed by hl registers pair
 
 	// registers pairs fast access
 	bc = iota
 	de
+	hl
+	sp
+	psw
 )
 
-type state struct {
+// CPU holds complete state of emulated 8080 processor
+type CPU struct {
 	a          uint8
 	b          uint8
 	c          uint8
@@ -27,30 +34,87 @@ type state struct {
 	e          uint8
 	h          uint8
 	l          uint8
-	sc         uint16
+	sp         uint16
 	pc         uint16
-	mem        []uint8
+	mem        Bus
+	ports      [256]IOHandler
 	cc         *condCodes
 	int_enable uint8
+	intDelay   bool    // set by EI so the next instruction runs before any interrupt
+	intRequest []uint8 // instruction placed on the data bus by interrupting device
+	injected   []uint8 // instruction being executed instead of memory at pc
+	halted     bool
+	cycles     uint64
+	taken      bool // set when condition of conditional instruction is met
+
+	recording *Recording // inputs are appended here while recording
+	replay    *Recording // inputs are taken from here while replaying
+	replayed  int        // number of replayed events
+	replayErr error      // set when replayed execution diverged from the recording
+
+	variant      Variant
+	opcodes      *[256]spec.Opcode
+	i85          i8085
+	undocumented UndocumentedPolicy
+}
+
+// Option configures CPU created with New
+type Option func(*CPU)
+
+// WithBus makes CPU use provided memory bus instead of flat 64K RAM
+func WithBus(bus Bus) Option {
+	return func(s *CPU) {
+		s.mem = bus
+	}
+}
+
+// UndocumentedPolicy tells CPU how to treat opcodes missing from 8080 documentation
+type UndocumentedPolicy int
+
+const (
+	// ExecuteUndocumented runs undocumented opcodes as the silicon does, as aliases of NOP, JMP, RET and CALL
+	ExecuteUndocumented UndocumentedPolicy = iota
+	// TrapUndocumented makes undocumented opcodes fail, which helps spotting programs gone off the rails
+	TrapUndocumented
+)
+
+// WithUndocumented sets policy for undocumented opcodes; by default they are executed
+func WithUndocumented(policy UndocumentedPolicy) Option {
+	return func(s *CPU) {
+		s.undocumented = policy
+	}
 }
 
-// New returns fresh state for 8080 emulator
-func New() *state {
-	return &state{
+// New returns fresh 8080 CPU; by default it's attached to 64K of zeroed RAM
+func New(opts ...Option) *CPU {
+	s := &CPU{
 		cc:  &condCodes{},
-		mem: make([]uint8, 65536),
+		mem: NewRAM(0x10000),
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+	s.opcodes = instructionSets[s.variant]
+
+	return s
 }
 
-// Emulate DOC TBA ---
-func (s *state) Emulate() error {
-	opCode := s.mem[s.pc:]
-	defer func() { s.pc++ }()
+// Emulate executes single instruction pointed by pc and returns number of cycles it took
+func (s *CPU) Emulate() (int, error) {
+	start := s.cycles
+	s.taken = false
+	pc := s.pc
+	opCode := s.fetch()
+	if s.unsupported(opCode) {
+		s.pc = pc // injected opcodes don't advance pc
+		return 0, fmt.Errorf("undocumented opcode %#02x at %#04x", opCode, s.pc)
+	}
 
-	switch opCode[0] {
+	switch opCode {
 	case 0x00: // NOP
 	case 0x01: // LXI B,D16
-		s.lxi(opCode[1], opCode[2], bc)
+		s.lxi(s.fetch16(), bc)
 	case 0x02: // STAX B
 		s.stax(bc)
 	case 0x03: // INX B
@@ -60,10 +124,10 @@ func (s *state) Emulate() error {
 	case 0x05: // DCR B
 		s.dcr(b)
 	case 0x06: // MVI B, D8
-		s.mvi(opCode[1], b)
+		s.mvi(s.fetch(), b)
 	case 0x07: // RLC
-		s.rlc(a)
-	case 0x08: // -
+		s.rlc()
+	case 0x08: // *NOP, undocumented
 	case 0x09: // DAD B
 		s.dad(bc)
 	case 0x0a: // LDAX B
@@ -71,174 +135,869 @@ func (s *state) Emulate() error {
 	case 0x0b: // DCX B
 		s.dcx(bc)
 	case 0x0c: // INR C
+		s.inr(c)
 	case 0x0d: // DCR C
+		s.dcr(c)
 	case 0x0e: // MVI C,D8
-		s.mvi(opCode[1], c)
-	case 0x0f: // RCC
-	case 0x10: // -
+		s.mvi(s.fetch(), c)
+	case 0x0f: // RRC
+		s.rrc()
+	case 0x10: // *NOP, undocumented
 	case 0x11: // LXI D,D16
-		s.lxi(opCode[1], opCode[2], de)
+		s.lxi(s.fetch16(), de)
 	case 0x12: // STAX D
 		s.stax(de)
 	case 0x13: // INX D
 		s.inx(de)
 	case 0x14: // INR D
+		s.inr(d)
 	case 0x15: // DCR D
+		s.dcr(d)
 	case 0x16: // MVI D, D8
-		s.mvi(opCode[1], d)
+		s.mvi(s.fetch(), d)
 	case 0x17: // RAL
-	case 0x18: // -
+		s.ral()
+	case 0x18: // *NOP, undocumented
 	case 0x19: // DAD D
+		s.dad(de)
 	case 0x1a: // LDAX D
 		s.ldax(de)
 	case 0x1b: // DCX D
 		s.dcx(de)
-	default:
-		return fmt.Errorf("bad opcode %v", opCode)
+	case 0x1c: // INR E
+		s.inr(e)
+	case 0x1d: // DCR E
+		s.dcr(e)
+	case 0x1e: // MVI E,D8
+		s.mvi(s.fetch(), e)
+	case 0x1f: // RAR
+		s.rar()
+	case 0x20: // *NOP, undocumented; RIM on 8085
+		if s.variant == Intel8085 {
+			s.rim()
+		}
+	case 0x21: // LXI H,D16
+		s.lxi(s.fetch16(), hl)
+	case 0x22: // SHLD adr
+		s.shld(s.fetch16())
+	case 0x23: // INX H
+		s.inx(hl)
+	case 0x24: // INR H
+		s.inr(h)
+	case 0x25: // DCR H
+		s.dcr(h)
+	case 0x26: // MVI H,D8
+		s.mvi(s.fetch(), h)
+	case 0x27: // DAA
+		s.daa()
+	case 0x28: // *NOP, undocumented
+	case 0x29: // DAD H
+		s.dad(hl)
+	case 0x2a: // LHLD adr
+		s.lhld(s.fetch16())
+	case 0x2b: // DCX H
+		s.dcx(hl)
+	case 0x2c: // INR L
+		s.inr(l)
+	case 0x2d: // DCR L
+		s.dcr(l)
+	case 0x2e: // MVI L, D8
+		s.mvi(s.fetch(), l)
+	case 0x2f: // CMA
+		s.cma()
+	case 0x30: // *NOP, undocumented; SIM on 8085
+		if s.variant == Intel8085 {
+			s.sim()
+		}
+	case 0x31: // LXI SP, D16
+		s.lxi(s.fetch16(), sp)
+	case 0x32: // STA adr
+		s.sta(s.fetch16())
+	case 0x33: // INX SP
+		s.inx(sp)
+	case 0x34: // INR M
+		s.inr(m)
+	case 0x35: // DCR M
+		s.dcr(m)
+	case 0x36: // MVI M,D8
+		s.mvi(s.fetch(), m)
+	case 0x37: // STC
+		s.stc()
+	case 0x38: // *NOP, undocumented
+	case 0x39: // DAD SP
+		s.dad(sp)
+	case 0x3a: // LDA adr
+		s.lda(s.fetch16())
+	case 0x3b: // DCX SP
+		s.dcx(sp)
+	case 0x3c: // INR A
+		s.inr(a)
+	case 0x3d: // DCR A
+		s.dcr(a)
+	case 0x3e: // MVI A,D8
+		s.mvi(s.fetch(), a)
+	case 0x3f: // CMC
+		s.cmc()
+	case 0x40: // MOV B,B
+		s.mov(b, b)
+	case 0x41: // MOV B,C
+		s.mov(b, c)
+	case 0x42: // MOV B,D
+		s.mov(b, d)
+	case 0x43: // MOV B,E
+		s.mov(b, e)
+	case 0x44: // MOV B,H
+		s.mov(b, h)
+	case 0x45: // MOV B,L
+		s.mov(b, l)
+	case 0x46: // MOV B,M
+		s.mov(b, m)
+	case 0x47: // MOV B,A
+		s.mov(b, a)
+	case 0x48: // MOV C,B
+		s.mov(c, b)
+	case 0x49: // MOV C,C
+		s.mov(c, c)
+	case 0x4a: // MOV C,D
+		s.mov(c, d)
+	case 0x4b: // MOV C,E
+		s.mov(c, e)
+	case 0x4c: // MOV C,H
+		s.mov(c, h)
+	case 0x4d: // MOV C,L
+		s.mov(c, l)
+	case 0x4e: // MOV C,M
+		s.mov(c, m)
+	case 0x4f: // MOV C,A
+		s.mov(c, a)
+	case 0x50: // MOV D,B
+		s.mov(d, b)
+	case 0x51: // MOV D,C
+		s.mov(d, c)
+	case 0x52: // MOV D,D
+		s.mov(d, d)
+	case 0x53: // MOV D,E
+		s.mov(d, e)
+	case 0x54: // MOV D,H
+		s.mov(d, h)
+	case 0x55: // MOV D,L
+		s.mov(d, l)
+	case 0x56: // MOV D,M
+		s.mov(d, m)
+	case 0x57: // MOV D,A
+		s.mov(d, a)
+	case 0x58: // MOV E,B
+		s.mov(e, b)
+	case 0x59: // MOV E,C
+		s.mov(e, c)
+	case 0x5a: // MOV E,D
+		s.mov(e, d)
+	case 0x5b: // MOV E,E
+		s.mov(e, e)
+	case 0x5c: // MOV E,H
+		s.mov(e, h)
+	case 0x5d: // MOV E,L
+		s.mov(e, l)
+	case 0x5e: // MOV E,M
+		s.mov(e, m)
+	case 0x5f: // MOV E,A
+		s.mov(e, a)
+	case 0x60: // MOV H,B
+		s.mov(h, b)
+	case 0x61: // MOV H,C
+		s.mov(h, c)
+	case 0x62: // MOV H,D
+		s.mov(h, d)
+	case 0x63: // MOV H,E
+		s.mov(h, e)
+	case 0x64: // MOV H,H
+		s.mov(h, h)
+	case 0x65: // MOV H,L
+		s.mov(h, l)
+	case 0x66: // MOV H,M
+		s.mov(h, m)
+	case 0x67: // MOV H,A
+		s.mov(h, a)
+	case 0x68: // MOV L,B
+		s.mov(l, b)
+	case 0x69: // MOV L,C
+		s.mov(l, c)
+	case 0x6a: // MOV L,D
+		s.mov(l, d)
+	case 0x6b: // MOV L,E
+		s.mov(l, e)
+	case 0x6c: // MOV L,H
+		s.mov(l, h)
+	case 0x6d: // MOV L,L
+		s.mov(l, l)
+	case 0x6e: // MOV L,M
+		s.mov(l, m)
+	case 0x6f: // MOV L,A
+		s.mov(l, a)
+	case 0x70: // MOV M,B
+		s.mov(m, b)
+	case 0x71: // MOV M,C
+		s.mov(m, c)
+	case 0x72: // MOV M,D
+		s.mov(m, d)
+	case 0x73: // MOV M,E
+		s.mov(m, e)
+	case 0x74: // MOV M,H
+		s.mov(m, h)
+	case 0x75: // MOV M,L
+		s.mov(m, l)
+	case 0x76: // HLT
+		s.halted = true
+	case 0x77: // MOV M,A
+		s.mov(m, a)
+	case 0x78: // MOV A,B
+		s.mov(a, b)
+	case 0x79: // MOV A,C
+		s.mov(a, c)
+	case 0x7a: // MOV A,D
+		s.mov(a, d)
+	case 0x7b: // MOV A,E
+		s.mov(a, e)
+	case 0x7c: // MOV A,H
+		s.mov(a, h)
+	case 0x7d: // MOV A,L
+		s.mov(a, l)
+	case 0x7e: // MOV A,M
+		s.mov(a, m)
+	case 0x7f: // MOV A,A
+		s.mov(a, a)
+	case 0x80: // ADD B
+		s.add(s.register(b))
+	case 0x81: // ADD C
+		s.add(s.register(c))
+	case 0x82: // ADD D
+		s.add(s.register(d))
+	case 0x83: // ADD E
+		s.add(s.register(e))
+	case 0x84: // ADD H
+		s.add(s.register(h))
+	case 0x85: // ADD L
+		s.add(s.register(l))
+	case 0x86: // ADD M
+		s.add(s.register(m))
+	case 0x87: // ADD A
+		s.add(s.register(a))
+	case 0x88: // ADC B
+		s.adc(s.register(b))
+	case 0x89: // ADC C
+		s.adc(s.register(c))
+	case 0x8a: // ADC D
+		s.adc(s.register(d))
+	case 0x8b: // ADC E
+		s.adc(s.register(e))
+	case 0x8c: // ADC H
+		s.adc(s.register(h))
+	case 0x8d: // ADC L
+		s.adc(s.register(l))
+	case 0x8e: // ADC M
+		s.adc(s.register(m))
+	case 0x8f: // ADC A
+		s.adc(s.register(a))
+	case 0x90: // SUB B
+		s.sub(s.register(b))
+	case 0x91: // SUB C
+		s.sub(s.register(c))
+	case 0x92: // SUB D
+		s.sub(s.register(d))
+	case 0x93: // SUB E
+		s.sub(s.register(e))
+	case 0x94: // SUB H
+		s.sub(s.register(h))
+	case 0x95: // SUB L
+		s.sub(s.register(l))
+	case 0x96: // SUB M
+		s.sub(s.register(m))
+	case 0x97: // SUB A
+		s.sub(s.register(a))
+	case 0x98: // SBB B
+		s.sbb(s.register(b))
+	case 0x99: // SBB C
+		s.sbb(s.register(c))
+	case 0x9a: // SBB D
+		s.sbb(s.register(d))
+	case 0x9b: // SBB E
+		s.sbb(s.register(e))
+	case 0x9c: // SBB H
+		s.sbb(s.register(h))
+	case 0x9d: // SBB L
+		s.sbb(s.register(l))
+	case 0x9e: // SBB M
+		s.sbb(s.register(m))
+	case 0x9f: // SBB A
+		s.sbb(s.register(a))
+	case 0xa0: // ANA B
+		s.ana(s.register(b))
+	case 0xa1: // ANA C
+		s.ana(s.register(c))
+	case 0xa2: // ANA D
+		s.ana(s.register(d))
+	case 0xa3: // ANA E
+		s.ana(s.register(e))
+	case 0xa4: // ANA H
+		s.ana(s.register(h))
+	case 0xa5: // ANA L
+		s.ana(s.register(l))
+	case 0xa6: // ANA M
+		s.ana(s.register(m))
+	case 0xa7: // ANA A
+		s.ana(s.register(a))
+	case 0xa8: // XRA B
+		s.xra(s.register(b))
+	case 0xa9: // XRA C
+		s.xra(s.register(c))
+	case 0xaa: // XRA D
+		s.xra(s.register(d))
+	case 0xab: // XRA E
+		s.xra(s.register(e))
+	case 0xac: // XRA H
+		s.xra(s.register(h))
+	case 0xad: // XRA L
+		s.xra(s.register(l))
+	case 0xae: // XRA M
+		s.xra(s.register(m))
+	case 0xaf: // XRA A
+		s.xra(s.register(a))
+	case 0xb0: // ORA B
+		s.ora(s.register(b))
+	case 0xb1: // ORA C
+		s.ora(s.register(c))
+	case 0xb2: // ORA D
+		s.ora(s.register(d))
+	case 0xb3: // ORA E
+		s.ora(s.register(e))
+	case 0xb4: // ORA H
+		s.ora(s.register(h))
+	case 0xb5: // ORA L
+		s.ora(s.register(l))
+	case 0xb6: // ORA M
+		s.ora(s.register(m))
+	case 0xb7: // ORA A
+		s.ora(s.register(a))
+	case 0xb8: // CMP B
+		s.cmp(s.register(b))
+	case 0xb9: // CMP C
+		s.cmp(s.register(c))
+	case 0xba: // CMP D
+		s.cmp(s.register(d))
+	case 0xbb: // CMP E
+		s.cmp(s.register(e))
+	case 0xbc: // CMP H
+		s.cmp(s.register(h))
+	case 0xbd: // CMP L
+		s.cmp(s.register(l))
+	case 0xbe: // CMP M
+		s.cmp(s.register(m))
+	case 0xbf: // CMP A
+		s.cmp(s.register(a))
+	case 0xc0: // RNZ
+		s.retIf(s.cc.z == 0)
+	case 0xc1: // POP B
+		s.pop(bc)
+	case 0xc2: // JNZ adr
+		s.jmpIf(s.cc.z == 0, s.fetch16())
+	case 0xc3: // JMP adr
+		s.jmp(s.fetch16())
+	case 0xc4: // CNZ adr
+		s.callIf(s.cc.z == 0, s.fetch16())
+	case 0xc5: // PUSH B
+		s.push(bc)
+	case 0xc6: // ADI D8
+		s.add(s.fetch())
+	case 0xc7: // RST 0
+		s.rst(0)
+	case 0xc8: // RZ
+		s.retIf(s.cc.z == 1)
+	case 0xc9: // RET
+		s.ret()
+	case 0xca: // JZ adr
+		s.jmpIf(s.cc.z == 1, s.fetch16())
+	case 0xcb: // *JMP adr, undocumented
+		s.jmp(s.fetch16())
+	case 0xcc: // CZ adr
+		s.callIf(s.cc.z == 1, s.fetch16())
+	case 0xcd: // CALL adr
+		s.call(s.fetch16())
+	case 0xce: // ACI D8
+		s.adc(s.fetch())
+	case 0xcf: // RST 1
+		s.rst(1)
+	case 0xd0: // RNC
+		s.retIf(s.cc.cy == 0)
+	case 0xd1: // POP D
+		s.pop(de)
+	case 0xd2: // JNC adr
+		s.jmpIf(s.cc.cy == 0, s.fetch16())
+	case 0xd3: // OUT D8
+		s.out(s.fetch())
+	case 0xd4: // CNC adr
+		s.callIf(s.cc.cy == 0, s.fetch16())
+	case 0xd5: // PUSH D
+		s.push(de)
+	case 0xd6: // SUI D8
+		s.sub(s.fetch())
+	case 0xd7: // RST 2
+		s.rst(2)
+	case 0xd8: // RC
+		s.retIf(s.cc.cy == 1)
+	case 0xd9: // *RET, undocumented
+		s.ret()
+	case 0xda: // JC adr
+		s.jmpIf(s.cc.cy == 1, s.fetch16())
+	case 0xdb: // IN D8
+		s.in(s.fetch())
+	case 0xdc: // CC adr
+		s.callIf(s.cc.cy == 1, s.fetch16())
+	case 0xdd: // *CALL adr, undocumented
+		s.call(s.fetch16())
+	case 0xde: // SBI D8
+		s.sbb(s.fetch())
+	case 0xdf: // RST 3
+		s.rst(3)
+	case 0xe0: // RPO
+		s.retIf(s.cc.p == 0)
+	case 0xe1: // POP H
+		s.pop(hl)
+	case 0xe2: // JPO adr
+		s.jmpIf(s.cc.p == 0, s.fetch16())
+	case 0xe3: // XTHL
+		s.xthl()
+	case 0xe4: // CPO adr
+		s.callIf(s.cc.p == 0, s.fetch16())
+	case 0xe5: // PUSH H
+		s.push(hl)
+	case 0xe6: // ANI D8
+		s.ana(s.fetch())
+	case 0xe7: // RST 4
+		s.rst(4)
+	case 0xe8: // RPE
+		s.retIf(s.cc.p == 1)
+	case 0xe9: // PCHL
+		s.pchl()
+	case 0xea: // JPE adr
+		s.jmpIf(s.cc.p == 1, s.fetch16())
+	case 0xeb: // XCHG
+		s.xchg()
+	case 0xec: // CPE adr
+		s.callIf(s.cc.p == 1, s.fetch16())
+	case 0xed: // *CALL adr, undocumented
+		s.call(s.fetch16())
+	case 0xee: // XRI D8
+		s.xra(s.fetch())
+	case 0xef: // RST 5
+		s.rst(5)
+	case 0xf0: // RP
+		s.retIf(s.cc.s == 0)
+	case 0xf1: // POP PSW
+		s.pop(psw)
+	case 0xf2: // JP adr
+		s.jmpIf(s.cc.s == 0, s.fetch16())
+	case 0xf3: // DI
+		s.int_enable = 0
+	case 0xf4: // CP adr
+		s.callIf(s.cc.s == 0, s.fetch16())
+	case 0xf5: // PUSH PSW
+		s.push(psw)
+	case 0xf6: // ORI D8
+		s.ora(s.fetch())
+	case 0xf7: // RST 6
+		s.rst(6)
+	case 0xf8: // RM
+		s.retIf(s.cc.s == 1)
+	case 0xf9: // SPHL
+		s.sphl()
+	case 0xfa: // JM adr
+		s.jmpIf(s.cc.s == 1, s.fetch16())
+	case 0xfb: // EI
+		s.ei()
+	case 0xfc: // CM adr
+		s.callIf(s.cc.s == 1, s.fetch16())
+	case 0xfd: // *CALL adr, undocumented
+		s.call(s.fetch16())
+	case 0xfe: // CPI D8
+		s.cmp(s.fetch())
+	case 0xff: // RST 7
+		s.rst(7)
 	}
 
-	return nil
+	s.cycles += uint64(cycles(&s.opcodes[opCode], s.taken))
+	if err := s.replayErr; err != nil {
+		s.replayErr = nil
+		return int(s.cycles - start), err
+	}
+	return int(s.cycles - start), nil
 }
 
 // dad "double adds" a 16bit value located in the provided registers pair and stores the result in
 // hl registers
-func (s *state) dad(regPair int) {
-	var result uint16
+func (s *CPU) dad(regPair int) {
+	result := uint32(s.pair(hl)) + uint32(s.pair(regPair))
+	s.setPair(hl, uint16(result))
+
+	if result > 0xffff {
+		s.cc.cy = 1
+	} else {
+		s.cc.cy = 0
+	}
+}
+
+// rlc rotates accumulator left; bit 7 goes to bit 0 and CY
+func (s *CPU) rlc() {
+	bit7 := s.a >> 7
+	s.a = s.a<<1 | bit7
+	s.cc.cy = bit7
+}
+
+// rrc rotates accumulator right; bit 0 goes to bit 7 and CY
+func (s *CPU) rrc() {
+	bit0 := s.a & 1
+	s.a = s.a>>1 | bit0<<7
+	s.cc.cy = bit0
+}
+
+// ral rotates accumulator left through carry; CY goes to bit 0 and bit 7 goes to CY
+func (s *CPU) ral() {
+	bit7 := s.a >> 7
+	s.a = s.a<<1 | s.cc.cy
+	s.cc.cy = bit7
+}
+
+// rar rotates accumulator right through carry; CY goes to bit 7 and bit 0 goes to CY
+func (s *CPU) rar() {
+	bit0 := s.a & 1
+	s.a = s.a>>1 | s.cc.cy<<7
+	s.cc.cy = bit0
+}
+
+// daa adjusts accumulator to form two 4bit binary coded decimal digits; both nibble corrections
+// are added at once, so high nibble is corrected as well when low nibble correction would overflow it.
+// AC reports carry out of bit 3 and CY is set when high nibble gets corrected, otherwise it's unaffected.
+func (s *CPU) daa() {
+	var correction uint8
+	cy := s.cc.cy
+	lsb, msb := s.a&0x0f, s.a>>4
 
-	switch regPair {
-	case bc:
-		bcValue := (uint16(s.b) << 8) + uint16(s.c)
-		hlValue := (uint16(s.h) << 8) + uint16(s.l)
+	if lsb > 9 || s.cc.ac == 1 {
+		correction |= 0x06
 	}
+	if msb > 9 || (msb == 9 && lsb > 9) || cy == 1 {
+		correction |= 0x60
+		cy = 1
+	}
+
+	result := uint16(s.a) + uint16(correction)
+	s.cc.setACAdd(s.a, correction, 0)
+	s.a = uint8(result)
+
+	s.cc.setZSP(result)
+	s.cc.cy = cy
+}
+
+// cma complements accumulator
+func (s *CPU) cma() {
+	s.a = ^s.a
+}
+
+// stc sets carry flag
+func (s *CPU) stc() {
+	s.cc.cy = 1
+}
+
+// cmc complements carry flag
+func (s *CPU) cmc() {
+	s.cc.cy ^= 1
+}
+
+// inr increments value of single register and sets proper Z, S, P and AC condition codes
+func (s *CPU) inr(reg int) {
+	val := s.register(reg)
+	result := uint16(val) + 1
+	s.setRegister(reg, uint8(result))
+
+	s.cc.setZSP(result)
+	s.cc.setACAdd(val, 1, 0)
+}
+
+// dcr decrements value of single register and sets proper Z, S, P and AC condition codes
+func (s *CPU) dcr(reg int) {
+	val := s.register(reg)
+	result := uint16(val) - 1
+	s.setRegister(reg, uint8(result))
 
+	s.cc.setZSP(result)
+	s.cc.setACSub(val, 1, 0)
+}
+
+// add adds provided value to accumulator and sets Z, S, P, CY and AC condition codes
+func (s *CPU) add(val uint8) {
+	result := uint16(s.a) + uint16(val)
+	s.cc.setACAdd(s.a, val, 0)
+	s.a = uint8(result)
+
+	s.cc.setZSP(result)
 	s.cc.setCY(result)
 }
 
-// rlc rotates accumulator left
-func (s *state) rlc(reg int) {
-	var result uint16
-	switch reg {
-	case a:
-		shiftedA := uint16(s.a) << 1
-		result = shiftedA | 1<<0
-		s.a = uint8(result)
-	}
+// adc adds provided value and carry bit to accumulator and sets Z, S, P, CY and AC condition codes
+func (s *CPU) adc(val uint8) {
+	result := uint16(s.a) + uint16(val) + uint16(s.cc.cy)
+	s.cc.setACAdd(s.a, val, s.cc.cy)
+	s.a = uint8(result)
 
+	s.cc.setZSP(result)
 	s.cc.setCY(result)
 }
 
-// inr increments value of single register and sets proper Z, S and P condition codes
-func (s *state) inr(reg int) {
-	var result uint16
+// sub subtracts provided value from accumulator and sets Z, S, P, CY and AC condition codes;
+// CY is set when borrow occurs
+func (s *CPU) sub(val uint8) {
+	result := uint16(s.a) - uint16(val)
+	s.cc.setACSub(s.a, val, 0)
+	s.a = uint8(result)
+
+	s.cc.setZSP(result)
+	s.cc.setCY(result)
+}
 
-	switch reg {
-	case b:
-		result = uint16(s.b) + 1
-		s.b++
+// sbb subtracts provided value and carry bit from accumulator and sets Z, S, P, CY and AC condition codes
+func (s *CPU) sbb(val uint8) {
+	result := uint16(s.a) - uint16(val) - uint16(s.cc.cy)
+	s.cc.setACSub(s.a, val, s.cc.cy)
+	s.a = uint8(result)
+
+	s.cc.setZSP(result)
+	s.cc.setCY(result)
+}
+
+// ana performs logical and of accumulator and provided value, sets Z, S, P, AC and resets CY;
+// 8085 always sets AC while 8080 sets it to the or of bits 3 of operands
+func (s *CPU) ana(val uint8) {
+	if s.variant == Intel8085 {
+		s.cc.ac = 1
+	} else {
+		s.cc.setACAnd(s.a, val)
 	}
+	s.a &= val
 
-	s.cc.setZ(result)
-	s.cc.setS(result)
-	s.cc.setP(result)
+	s.cc.setZSP(uint16(s.a))
+	s.cc.cy = 0
 }
 
-// dcr decrements value of single register and sets proper Z, S and P condition codes
-func (s *state) dcr(reg int) {
-	var result uint16
+// xra performs exclusive or of accumulator and provided value, sets Z, S, P and resets CY and AC
+func (s *CPU) xra(val uint8) {
+	s.a ^= val
 
-	switch reg {
-	case b:
-		result = uint16(s.b) - 1
-		s.b--
-	}
+	s.cc.setZSP(uint16(s.a))
+	s.cc.cy = 0
+	s.cc.ac = 0
+}
 
-	s.cc.setZ(result)
-	s.cc.setS(result)
-	s.cc.setP(result)
+// ora performs logical or of accumulator and provided value, sets Z, S, P and resets CY and AC
+func (s *CPU) ora(val uint8) {
+	s.a |= val
+
+	s.cc.setZSP(uint16(s.a))
+	s.cc.cy = 0
+	s.cc.ac = 0
 }
 
-// inx increments values stored in provided registers pair
-func (s *state) inx(regPair int) {
-	switch regPair {
-	case bc:
-		s.b++
-		s.c++
-	case de:
-		s.d++
-		s.e++
-	}
+// cmp compares provided value with accumulator by subtracting it without storing the result
+func (s *CPU) cmp(val uint8) {
+	result := uint16(s.a) - uint16(val)
+	s.cc.setACSub(s.a, val, 0)
+
+	s.cc.setZSP(result)
+	s.cc.setCY(result)
 }
 
-// inx decrements values stored in provided registers pair
-func (s *state) dcx(regPair int) {
-	switch regPair {
-	case bc:
-		s.b--
-		s.c--
-	case de:
-		s.d--
-		s.e--
-	}
+// inx increments 16bit value stored in provided registers pair
+func (s *CPU) inx(regPair int) {
+	s.setPair(regPair, s.pair(regPair)+1)
+}
+
+// dcx decrements 16bit value stored in provided registers pair
+func (s *CPU) dcx(regPair int) {
+	s.setPair(regPair, s.pair(regPair)-1)
 }
 
 // ldax loads value stored in memory address provided by registers pair in accumulator
-func (s *state) ldax(regPair int) {
-	var address uint16
+func (s *CPU) ldax(regPair int) {
+	s.a = s.mem.Read(s.pair(regPair))
+}
 
-	switch regPair {
-	case bc:
-		address = addr(s.b, s.c)
-	case de:
-		address = addr(s.d, s.e)
-	}
+// stax stores data from acumulator to memory address provided from registers pair
+func (s *CPU) stax(regPair int) {
+	s.mem.Write(s.pair(regPair), s.a)
+}
 
-	s.a = s.mem[address]
+// lda loads value stored at provided address to accumulator
+func (s *CPU) lda(address uint16) {
+	s.a = s.mem.Read(address)
 }
 
-// stax stores data from acumulator to memory address provided from registers pair
-func (s *state) stax(regPair int) {
-	var address uint16
+// sta stores accumulator at provided address
+func (s *CPU) sta(address uint16) {
+	s.mem.Write(address, s.a)
+}
+
+// lhld loads l and h registers from provided address and the next one
+func (s *CPU) lhld(address uint16) {
+	s.l = s.mem.Read(address)
+	s.h = s.mem.Read(address + 1)
+}
+
+// shld stores l and h registers at provided address and the next one
+func (s *CPU) shld(address uint16) {
+	s.mem.Write(address, s.l)
+	s.mem.Write(address+1, s.h)
+}
+
+// lxi loads provided 16bit value into provided registers pair
+func (s *CPU) lxi(val uint16, regPair int) {
+	s.setPair(regPair, val)
+}
+
+// mvi moves 8bit value to provided register
+func (s *CPU) mvi(val uint8, reg int) {
+	s.setRegister(reg, val)
+}
 
-	switch regPair {
-	case bc:
-		address = addr(s.b, s.c)
-	case de:
-		address = addr(s.d, s.e)
+// mov copies value of src register to dst register
+func (s *CPU) mov(dst, src int) {
+	s.setRegister(dst, s.register(src))
+}
+
+// xchg swaps hl registers pair with de registers pair
+func (s *CPU) xchg() {
+	hlValue := s.pair(hl)
+	s.setPair(hl, s.pair(de))
+	s.setPair(de, hlValue)
+}
+
+// xthl swaps hl registers pair with two bytes stored on top of the stack
+func (s *CPU) xthl() {
+	hlValue := s.pair(hl)
+	s.setPair(hl, s.pop16())
+	s.push16(hlValue)
+}
+
+// sphl loads stack pointer from hl registers pair
+func (s *CPU) sphl() {
+	s.setPair(sp, s.pair(hl))
+}
+
+// pchl jumps to address stored in hl registers pair
+func (s *CPU) pchl() {
+	s.pc = s.pair(hl)
+}
+
+// push stores provided registers pair on top of the stack
+func (s *CPU) push(regPair int) {
+	s.push16(s.pair(regPair))
+}
+
+// pop loads two bytes from top of the stack to provided registers pair
+func (s *CPU) pop(regPair int) {
+	s.setPair(regPair, s.pop16())
+}
+
+// jmp sets pc to provided address
+func (s *CPU) jmp(address uint16) {
+	s.pc = address
+}
+
+// jmpIf jumps to provided address when condition is met
+func (s *CPU) jmpIf(condition bool, address uint16) {
+	if condition {
+		s.jmp(address)
+		s.taken = true
 	}
+}
 
-	s.mem[address] = s.a
+// call pushes address of the next instruction on the stack and jumps to provided address
+func (s *CPU) call(address uint16) {
+	s.push16(s.pc)
+	s.jmp(address)
 }
 
-// lxi loads provided 16bit value into provided registers pair and increments pc by two
-func (s *state) lxi(valA, valB uint8, regPair int) {
-	switch regPair {
-	case bc:
-		s.b = valB
-		s.c = valA
-	case de:
-		s.d = valB
-		s.e = valA
+// callIf calls provided address when condition is met
+func (s *CPU) callIf(condition bool, address uint16) {
+	if condition {
+		s.call(address)
+		s.taken = true
 	}
+}
 
-	s.pc += 2
+// ret pops return address from the stack and jumps to it
+func (s *CPU) ret() {
+	s.pc = s.pop16()
 }
 
-// mvi moves 8bit value to provided register and increases pc by one
-func (s *state) mvi(val uint8, reg int) {
-	switch reg {
-	case b:
-		s.b = val
-	case c:
-		s.c = val
-	case d:
-		s.d = val
-	case h:
-		s.h = val
-	case l:
-		s.l = val
+// retIf returns when condition is met
+func (s *CPU) retIf(condition bool) {
+	if condition {
+		s.ret()
+		s.taken = true
 	}
+}
+
+// rst calls one of eight restart routines located at n*8 address
+func (s *CPU) rst(n uint16) {
+	s.push16(s.pc)
+	s.pc = n * 8
+}
 
+// push16 stores 16bit value on top of the stack with high byte at the higher address
+func (s *CPU) push16(val uint16) {
+	s.sp -= 2
+	s.mem.Write(s.sp, uint8(val))
+	s.mem.Write(s.sp+1, uint8(val>>8))
+}
+
+// pop16 loads 16bit value from top of the stack
+func (s *CPU) pop16() uint16 {
+	val := addr(s.mem.Read(s.sp+1), s.mem.Read(s.sp))
+	s.sp += 2
+
+	return val
+}
+
+// fetch reads the next instruction byte and advances pc past it, wrapping around at the top of memory;
+// while serving an interrupt bytes come from the data bus and pc stays put
+func (s *CPU) fetch() uint8 {
+	if s.injected != nil {
+		if len(s.injected) == 0 {
+			return openBus
+		}
+		val := s.injected[0]
+		s.injected = s.injected[1:]
+		return val
+	}
+
+	val := s.mem.Read(s.pc)
 	s.pc++
+	return val
+}
+
+// fetch16 reads the next two instruction bytes as a little endian 16bit value
+func (s *CPU) fetch16() uint16 {
+	lo := s.fetch()
+	hi := s.fetch()
+	return addr(hi, lo)
+}
+
+// unsupported reports whether provided opcode fails instead of being executed; 8085 doesn't alias
+// undocumented opcodes of 8080, so they always fail there
+func (s *CPU) unsupported(opCode uint8) bool {
+	if !s.opcodes[opCode].Undocumented {
+		return false
+	}
+
+	return s.variant == Intel8085 || s.undocumented == TrapUndocumented
 }
 
 func addr(a, b uint8) uint16 {
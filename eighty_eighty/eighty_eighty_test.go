@@ -1,6 +1,11 @@
 package eighty_eighty
 
 import (
+	"bufio"
+	"fmt"
+	"log"
+	"os"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -11,8 +16,8 @@ func TestRLC(t *testing.T) {
 		ee := New()
 		ee.a = 0x0a
 
-		ee.rlc(a)
-		assert.Equal(t, uint8(0x15), ee.a, "rotates accumulator one bit left")
+		ee.rlc()
+		assert.Equal(t, uint8(0x14), ee.a, "rotates accumulator one bit left")
 	})
 
 	t.Run("setting carry bit", func(t *testing.T) {
@@ -20,7 +25,7 @@ func TestRLC(t *testing.T) {
 			ee := New()
 			ee.a = 0xff
 
-			ee.rlc(a)
+			ee.rlc()
 			assert.Equal(t, uint8(0xff), ee.a, "rotates accumulator one bit left")
 			assert.Equal(t, uint8(1), ee.cc.cy, "sets CY flag")
 		})
@@ -29,13 +34,41 @@ func TestRLC(t *testing.T) {
 			ee := New()
 			ee.a = 0x00
 
-			ee.rlc(a)
-			assert.Equal(t, uint8(0x01), ee.a, "rotates accumulator one bit left")
+			ee.rlc()
+			assert.Equal(t, uint8(0x00), ee.a, "rotates accumulator one bit left")
 			assert.Equal(t, uint8(0), ee.cc.cy, "does not set CY flag")
 		})
 	})
 }
 
+func TestRRC(t *testing.T) {
+	ee := New()
+	ee.a = 0xf3
+
+	ee.rrc()
+	assert.Equal(t, uint8(0xf9), ee.a, "rotates accumulator one bit right")
+	assert.Equal(t, uint8(1), ee.cc.cy, "sets CY flag to previous bit 0")
+}
+
+func TestRAL(t *testing.T) {
+	ee := New()
+	ee.a = 0xb5
+
+	ee.ral()
+	assert.Equal(t, uint8(0x6a), ee.a, "rotates accumulator one bit left through carry")
+	assert.Equal(t, uint8(1), ee.cc.cy, "sets CY flag to previous bit 7")
+}
+
+func TestRAR(t *testing.T) {
+	ee := New()
+	ee.a = 0x6a
+	ee.cc.cy = 1
+
+	ee.rar()
+	assert.Equal(t, uint8(0xb5), ee.a, "rotates accumulator one bit right through carry")
+	assert.Equal(t, uint8(0), ee.cc.cy, "sets CY flag to previous bit 0")
+}
+
 func TestINR(t *testing.T) {
 	t.Run("incrementing register", func(t *testing.T) {
 		ee := New()
@@ -98,6 +131,14 @@ func TestINR(t *testing.T) {
 			assert.Zero(t, ee.cc.p, "keeps parity flag equal to zero")
 		})
 	})
+
+	t.Run("setting auxiliary carry flag", func(t *testing.T) {
+		ee := New()
+		ee.b = 0x0f
+
+		ee.inr(b)
+		assert.Equal(t, uint8(0x01), ee.cc.ac, "sets auxiliary carry flag when low nibble overflows")
+	})
 }
 
 func TestDCR(t *testing.T) {
@@ -162,71 +203,84 @@ func TestDCR(t *testing.T) {
 			assert.Zero(t, ee.cc.p, "keeps parity flag equal to zero")
 		})
 	})
+
+	t.Run("setting auxiliary carry flag", func(t *testing.T) {
+		ee := New()
+		ee.b = 0x10
+
+		ee.dcr(b)
+		assert.Zero(t, ee.cc.ac, "resets auxiliary carry flag when low nibble borrows")
+
+		ee.dcr(b)
+		assert.Equal(t, uint8(0x01), ee.cc.ac, "sets auxiliary carry flag when low nibble does not borrow")
+	})
 }
 
 func TestLXI(t *testing.T) {
 	ee := New()
 
 	pairs := []struct {
-		registersPair  int
+		opCode         uint8
 		firstRegister  *uint8
 		secondRegister *uint8
 	}{
-		{bc, &ee.b, &ee.c},
-		{de, &ee.d, &ee.e},
+		{0x01, &ee.b, &ee.c},
+		{0x11, &ee.d, &ee.e},
 	}
 
 	for _, testCase := range pairs {
 		ee.pc = 0
+		ee.Load(0, []byte{testCase.opCode, 0x01, 0x02})
 
-		ee.lxi(0x01, 0x02, testCase.registersPair)
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
 		assert.Equal(t, uint8(0x01), *testCase.secondRegister, "sets 2nd byte to pair's first register")
 		assert.Equal(t, uint8(0x02), *testCase.firstRegister, "sets 3rd byte to pair's second register")
-		assert.Equal(t, uint16(2), ee.pc, "increments pc by two additional steps")
+		assert.Equal(t, uint16(3), ee.pc, "moves pc past the operand")
 	}
 }
 
 func TestEmulation(t *testing.T) {
 	t.Run("when NOP", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x00}
+		ee.Load(0, []byte{0x00})
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when STAX B", func(t *testing.T) {
 		ee := New()
-		ee.mem[0] = 0x02
+		ee.mem.Write(0, 0x02)
 		ee.a = 0x1f
 		ee.b = 0x01
 		ee.c = 0x02
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, ee.a, ee.mem[0x0102], "stores accumulator's value at memory address from registers b and c")
+		assert.Equal(t, ee.a, ee.mem.Read(0x0102), "stores accumulator's value at memory address from registers b and c")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when INX B", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x03}
+		ee.Load(0, []byte{0x03})
 		ee.b = 0x00
 		ee.c = 0x01
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, uint8(0x01), ee.b, "increments register b by one")
+		assert.Equal(t, uint8(0x00), ee.b, "keeps register b")
 		assert.Equal(t, uint8(0x02), ee.c, "increments register c by one")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when MVI B, D8", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x06, 0x1f}
+		ee.Load(0, []byte{0x06, 0x1f})
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
 		assert.Equal(t, uint8(0x1f), ee.b, "sets register b to 2nd byte")
 		assert.Equal(t, uint16(2), ee.pc, "increments pc by two")
@@ -236,33 +290,33 @@ func TestEmulation(t *testing.T) {
 		ee := New()
 		ee.b = 0x01
 		ee.c = 0x02
-		ee.mem[0] = 0x0a
-		ee.mem[0x0102] = 0xff
+		ee.mem.Write(0, 0x0a)
+		ee.mem.Write(0x0102, 0xff)
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, ee.mem[0x0102], ee.a, "loads value from memory addr stored in registers b and c to accumulator")
+		assert.Equal(t, ee.mem.Read(0x0102), ee.a, "loads value from memory addr stored in registers b and c to accumulator")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when DCX B", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x0b}
+		ee.Load(0, []byte{0x0b})
 		ee.b = 0x00
 		ee.c = 0x01
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, uint8(0xff), ee.b, "decrements register b by one")
+		assert.Equal(t, uint8(0x00), ee.b, "keeps register b")
 		assert.Equal(t, uint8(0x00), ee.c, "decrements register c by one")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when MVI C, D8", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x0e, 0x1f}
+		ee.Load(0, []byte{0x0e, 0x1f})
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
 		assert.Equal(t, uint8(0x1f), ee.c, "sets register c to 2nd byte")
 		assert.Equal(t, uint16(2), ee.pc, "increments pc by two")
@@ -270,35 +324,35 @@ func TestEmulation(t *testing.T) {
 
 	t.Run("when STAX D", func(t *testing.T) {
 		ee := New()
-		ee.mem[0] = 0x12
+		ee.mem.Write(0, 0x12)
 		ee.a = 0x1f
 		ee.d = 0x01
 		ee.e = 0x02
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, ee.a, ee.mem[0x0102], "stores accumulator's value at memory address from registers d and e")
+		assert.Equal(t, ee.a, ee.mem.Read(0x0102), "stores accumulator's value at memory address from registers d and e")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when INX D", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x13}
+		ee.Load(0, []byte{0x13})
 		ee.d = 0x00
 		ee.e = 0x01
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, uint8(0x01), ee.d, "increments register d by one")
+		assert.Equal(t, uint8(0x00), ee.d, "keeps register d")
 		assert.Equal(t, uint8(0x02), ee.e, "increments register e by one")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when MVI D, D8", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x16, 0x1f}
+		ee.Load(0, []byte{0x16, 0x1f})
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
 		assert.Equal(t, uint8(0x1f), ee.d, "sets register c to 2nd byte")
 		assert.Equal(t, uint16(2), ee.pc, "increments pc by two")
@@ -308,29 +362,492 @@ func TestEmulation(t *testing.T) {
 		ee := New()
 		ee.d = 0x01
 		ee.e = 0x02
-		ee.mem[0] = 0x1a
-		ee.mem[0x0102] = 0xff
+		ee.mem.Write(0, 0x1a)
+		ee.mem.Write(0x0102, 0xff)
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, ee.mem[0x0102], ee.a, "loads value from memory addr stored in registers b and c to accumulator")
+		assert.Equal(t, ee.mem.Read(0x0102), ee.a, "loads value from memory addr stored in registers b and c to accumulator")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 
 	t.Run("when DCX D", func(t *testing.T) {
 		ee := New()
-		ee.mem = []uint8{0x1b}
+		ee.Load(0, []byte{0x1b})
 		ee.d = 0x00
 		ee.e = 0x01
 
-		err := ee.Emulate()
+		_, err := ee.Emulate()
 		assert.Nil(t, err)
-		assert.Equal(t, uint8(0xff), ee.d, "decrements register d by one")
+		assert.Equal(t, uint8(0x00), ee.d, "keeps register d")
 		assert.Equal(t, uint8(0x00), ee.e, "decrements register e by one")
 		assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
 	})
 }
 
+func TestArithmetic(t *testing.T) {
+	testCases := []struct {
+		name     string
+		op       func(ee *CPU, val uint8)
+		a        uint8
+		val      uint8
+		cy       uint8
+		expected uint8
+		flags    condCodes
+	}{
+		{"ADD", (*CPU).add, 0x6c, 0x2e, 0, 0x9a, condCodes{s: 1, p: 1, ac: 1}},
+		{"ADD with carry out", (*CPU).add, 0xff, 0x01, 0, 0x00, condCodes{z: 1, p: 1, cy: 1, ac: 1}},
+		{"ADC", (*CPU).adc, 0x3d, 0x42, 1, 0x80, condCodes{s: 1, ac: 1}},
+		{"SUB", (*CPU).sub, 0x3e, 0x3e, 0, 0x00, condCodes{z: 1, p: 1, ac: 1}},
+		{"SUB with borrow", (*CPU).sub, 0x01, 0x02, 0, 0xff, condCodes{s: 1, p: 1, cy: 1}},
+		{"SBB", (*CPU).sbb, 0x04, 0x02, 1, 0x01, condCodes{ac: 1}},
+		{"ANA", (*CPU).ana, 0xfc, 0x0f, 1, 0x0c, condCodes{p: 1, ac: 1}},
+		{"XRA", (*CPU).xra, 0x5c, 0x78, 1, 0x24, condCodes{p: 1}},
+		{"ORA", (*CPU).ora, 0x33, 0x0f, 1, 0x3f, condCodes{p: 1}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ee := New()
+			ee.a = testCase.a
+			ee.cc.cy = testCase.cy
+
+			testCase.op(ee, testCase.val)
+			assert.Equal(t, testCase.expected, ee.a, "stores result in accumulator")
+			assert.Equal(t, testCase.flags, *ee.cc, "sets condition codes")
+		})
+	}
+
+	t.Run("CMP", func(t *testing.T) {
+		ee := New()
+		ee.a = 0x0a
+
+		ee.cmp(0x05)
+		assert.Equal(t, uint8(0x0a), ee.a, "does not change accumulator")
+		assert.Zero(t, ee.cc.cy, "does not set CY flag when accumulator is bigger")
+
+		ee.cmp(0x0b)
+		assert.Equal(t, uint8(1), ee.cc.cy, "sets CY flag when accumulator is smaller")
+
+		ee.cmp(0x0a)
+		assert.Equal(t, uint8(1), ee.cc.z, "sets Z flag when values are equal")
+	})
+}
+
+func TestDAD(t *testing.T) {
+	t.Run("adding registers pair to hl", func(t *testing.T) {
+		ee := New()
+		ee.b, ee.c = 0x33, 0x9f
+		ee.h, ee.l = 0xa1, 0x7b
+
+		ee.dad(bc)
+		assert.Equal(t, uint8(0xd5), ee.h, "stores high byte of the sum in h")
+		assert.Equal(t, uint8(0x1a), ee.l, "stores low byte of the sum in l")
+		assert.Zero(t, ee.cc.cy, "does not set CY flag")
+	})
+
+	t.Run("when result overflows 16 bits", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x0002
+		ee.h, ee.l = 0xff, 0xff
+
+		ee.dad(sp)
+		assert.Equal(t, uint8(0x00), ee.h, "wraps high byte")
+		assert.Equal(t, uint8(0x01), ee.l, "wraps low byte")
+		assert.Equal(t, uint8(1), ee.cc.cy, "sets CY flag")
+	})
+}
+
+func TestDAA(t *testing.T) {
+	t.Run("adjusting accumulator", func(t *testing.T) {
+		ee := New()
+		ee.a = 0x9b
+
+		ee.daa()
+		assert.Equal(t, uint8(0x01), ee.a, "adjusts accumulator to BCD")
+		assert.Equal(t, uint8(1), ee.cc.cy, "sets CY flag")
+		assert.Equal(t, uint8(1), ee.cc.ac, "sets AC flag")
+	})
+
+	t.Run("adding BCD numbers", func(t *testing.T) {
+		ee := New()
+		ee.a = 0x38
+
+		ee.add(0x49)
+		ee.daa()
+		assert.Equal(t, uint8(0x87), ee.a, "stores BCD sum in accumulator")
+		assert.Zero(t, ee.cc.cy, "does not set CY flag")
+	})
+
+	t.Run("matching every input combination", func(t *testing.T) {
+		file, err := os.Open("testdata/daa.txt")
+		if err != nil {
+			log.Fatalf("cant open DAA table: %s", err.Error())
+		}
+		defer file.Close()
+
+		var checked int
+		scanner := bufio.NewScanner(file)
+		for scanner.Scan() {
+			line := scanner.Text()
+			if strings.HasPrefix(line, "#") {
+				continue
+			}
+
+			var in, out uint8
+			var inFlags, expected condCodes
+			_, err := fmt.Sscanf(line, "%x %d %d | %x %d %d %d %d %d", &in, &inFlags.ac, &inFlags.cy,
+				&out, &expected.s, &expected.z, &expected.ac, &expected.p, &expected.cy)
+			if err != nil {
+				log.Fatalf("cant parse DAA table line %q: %s", line, err.Error())
+			}
+
+			ee := New()
+			ee.a = in
+			*ee.cc = inFlags
+
+			ee.daa()
+			assert.Equal(t, out, ee.a, "adjusts accumulator for %q", line)
+			assert.Equal(t, expected, *ee.cc, "sets condition codes for %q", line)
+			checked++
+		}
+
+		assert.Equal(t, 256*2*2, checked, "checks all accumulator, AC and CY combinations")
+	})
+}
+
+func TestStack(t *testing.T) {
+	t.Run("pushing and popping registers pair", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x3a2c
+		ee.d, ee.e = 0x8f, 0x9d
+
+		ee.push(de)
+		assert.Equal(t, uint16(0x3a2a), ee.sp, "decrements stack pointer by two")
+		assert.Equal(t, uint8(0x8f), ee.mem.Read(0x3a2b), "stores high byte at sp-1")
+		assert.Equal(t, uint8(0x9d), ee.mem.Read(0x3a2a), "stores low byte at sp-2")
+
+		ee.pop(bc)
+		assert.Equal(t, uint16(0x3a2c), ee.sp, "increments stack pointer by two")
+		assert.Equal(t, uint8(0x8f), ee.b, "loads high byte")
+		assert.Equal(t, uint8(0x9d), ee.c, "loads low byte")
+	})
+
+	t.Run("pushing and popping psw", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2000
+		ee.a = 0x1f
+		ee.cc = &condCodes{z: 1, cy: 1}
+
+		ee.push(psw)
+		assert.Equal(t, uint8(0x1f), ee.mem.Read(0x1fff), "stores accumulator at sp-1")
+		assert.Equal(t, uint8(0x43), ee.mem.Read(0x1ffe), "stores flag byte with fixed bit 1 at sp-2")
+		ee.a = 0
+		ee.cc = &condCodes{}
+
+		ee.pop(psw)
+		assert.Equal(t, uint8(0x1f), ee.a, "restores accumulator")
+		assert.Equal(t, condCodes{z: 1, cy: 1}, *ee.cc, "restores condition codes")
+	})
+}
+
+func TestBranching(t *testing.T) {
+	t.Run("when JMP", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xc3)
+		ee.mem.Write(1, 0x00)
+		ee.mem.Write(2, 0x3e)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x3e00), ee.pc, "sets pc to provided address")
+	})
+
+	t.Run("when JNZ and zero flag is set", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xc2)
+		ee.cc.z = 1
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(3), ee.pc, "skips jump address")
+	})
+
+	t.Run("when CALL and RET", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2400
+		ee.pc = 0x0100
+		ee.mem.Write(0x0100, 0xcd)
+		ee.mem.Write(0x0101, 0x00)
+		ee.mem.Write(0x0102, 0x02)
+		ee.mem.Write(0x0200, 0xc9)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0200), ee.pc, "jumps to called address")
+		assert.Equal(t, uint16(0x23fe), ee.sp, "pushes return address on the stack")
+
+		_, err = ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0103), ee.pc, "returns to instruction after call")
+		assert.Equal(t, uint16(0x2400), ee.sp, "pops return address from the stack")
+	})
+
+	t.Run("when RC and carry flag is not set", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xd8)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(1), ee.pc, "does not return")
+	})
+
+	t.Run("when RST 1", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2400
+		ee.pc = 0x0100
+		ee.mem.Write(0x0100, 0xcf)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0008), ee.pc, "jumps to restart routine")
+		assert.Equal(t, uint8(0x01), ee.mem.Read(0x23ff), "pushes high byte of return address")
+		assert.Equal(t, uint8(0x01), ee.mem.Read(0x23fe), "pushes low byte of return address")
+	})
+
+	t.Run("when PCHL", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xe9)
+		ee.h, ee.l = 0x41, 0x3e
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x413e), ee.pc, "jumps to address stored in hl")
+	})
+}
+
+func TestWraparound(t *testing.T) {
+	t.Run("when instruction is at the last address", func(t *testing.T) {
+		ee := New()
+		ee.pc = 0xffff
+		ee.mem.Write(0xffff, 0x00)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0), ee.pc, "wraps pc to the bottom of memory")
+	})
+
+	t.Run("when operand straddles the top of memory", func(t *testing.T) {
+		ee := New()
+		ee.pc = 0xfffe
+		ee.Load(0xfffe, []byte{0x21, 0x3e})
+		ee.mem.Write(0x0000, 0x41)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x413e), ee.pair(hl), "reads operand bytes across the wrap")
+		assert.Equal(t, uint16(0x0001), ee.pc, "moves pc past the operand")
+	})
+
+	t.Run("when jumping into the top of memory", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0xc3, 0xff, 0xff}) // JMP 0xffff
+		ee.mem.Write(0xffff, 0xc3)           // JMP 0x0000, operand taken from the bottom of memory
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0xffff), ee.pc, "jumps to the last address")
+
+		_, err = ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0xffc3), ee.pc, "jumps to address read across the wrap")
+	})
+
+	t.Run("when CALL straddles the top of memory", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2400
+		ee.pc = 0xffff
+		ee.mem.Write(0xffff, 0xcd)
+		ee.Load(0, []byte{0x00, 0x02})
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0200), ee.pc, "jumps to called address")
+		assert.Equal(t, uint16(0x0002), ee.pop16(), "pushes wrapped return address")
+	})
+
+	t.Run("when LHLD reads the last address", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0x2a, 0xff, 0xff})
+		ee.mem.Write(0xffff, 0x3e)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x3e), ee.l, "loads l from the last address")
+		assert.Equal(t, uint8(0x2a), ee.h, "loads h from the bottom of memory")
+	})
+}
+
+func TestDataTransfer(t *testing.T) {
+	t.Run("when MOV B,C", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0x41)
+		ee.c = 0x1f
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x1f), ee.b, "copies register c to register b")
+	})
+
+	t.Run("when MOV M,A", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0x77)
+		ee.a = 0x1f
+		ee.h, ee.l = 0x20, 0x00
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x1f), ee.mem.Read(0x2000), "copies accumulator to memory addressed by hl")
+	})
+
+	t.Run("when STA and LDA", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0x32)
+		ee.mem.Write(1, 0x34)
+		ee.mem.Write(2, 0x12)
+		ee.mem.Write(3, 0x3a)
+		ee.mem.Write(4, 0x34)
+		ee.mem.Write(5, 0x12)
+		ee.a = 0x1f
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x1f), ee.mem.Read(0x1234), "stores accumulator at provided address")
+		assert.Equal(t, uint16(3), ee.pc, "increments pc by three")
+
+		ee.a = 0
+		_, err = ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x1f), ee.a, "loads accumulator from provided address")
+		assert.Equal(t, uint16(6), ee.pc, "increments pc by three")
+	})
+
+	t.Run("when SHLD and LHLD", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0x22)
+		ee.mem.Write(1, 0x0a)
+		ee.mem.Write(2, 0x01)
+		ee.mem.Write(3, 0x2a)
+		ee.mem.Write(4, 0x0a)
+		ee.mem.Write(5, 0x01)
+		ee.h, ee.l = 0xae, 0x29
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x29), ee.mem.Read(0x010a), "stores l at provided address")
+		assert.Equal(t, uint8(0xae), ee.mem.Read(0x010b), "stores h at the next address")
+
+		ee.h, ee.l = 0, 0
+		_, err = ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0xae), ee.h, "loads h from the next address")
+		assert.Equal(t, uint8(0x29), ee.l, "loads l from provided address")
+	})
+
+	t.Run("when XCHG", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xeb)
+		ee.d, ee.e = 0x33, 0x55
+		ee.h, ee.l = 0x00, 0xff
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, []uint8{0x00, 0xff, 0x33, 0x55}, []uint8{ee.d, ee.e, ee.h, ee.l}, "swaps de and hl")
+	})
+
+	t.Run("when XTHL", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xe3)
+		ee.sp = 0x10ad
+		ee.mem.Write(0x10ad, 0xf0)
+		ee.mem.Write(0x10ae, 0x0d)
+		ee.h, ee.l = 0x0b, 0x3c
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x0d), ee.h, "loads h from sp+1")
+		assert.Equal(t, uint8(0xf0), ee.l, "loads l from sp")
+		assert.Equal(t, uint8(0x0b), ee.mem.Read(0x10ae), "stores h at sp+1")
+		assert.Equal(t, uint8(0x3c), ee.mem.Read(0x10ad), "stores l at sp")
+	})
+
+	t.Run("when SPHL", func(t *testing.T) {
+		ee := New()
+		ee.mem.Write(0, 0xf9)
+		ee.h, ee.l = 0x50, 0x6c
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x506c), ee.sp, "loads stack pointer from hl")
+	})
+}
+
+func TestUndocumented(t *testing.T) {
+	t.Run("when *NOP", func(t *testing.T) {
+		for _, opCode := range []uint8{0x08, 0x10, 0x18, 0x20, 0x28, 0x30, 0x38} {
+			ee := New()
+			ee.mem.Write(0, opCode)
+
+			spent, err := ee.Emulate()
+			assert.Nil(t, err)
+			assert.Equal(t, uint16(1), ee.pc, "increments pc by one")
+			assert.Equal(t, 4, spent, "takes as long as NOP")
+		}
+	})
+
+	t.Run("when *JMP", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0xcb, 0x00, 0x3e})
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x3e00), ee.pc, "sets pc to provided address")
+	})
+
+	t.Run("when *CALL and *RET", func(t *testing.T) {
+		for _, opCode := range []uint8{0xdd, 0xed, 0xfd} {
+			ee := New()
+			ee.sp = 0x2400
+			ee.pc = 0x0100
+			ee.Load(0x0100, []byte{opCode, 0x00, 0x02})
+			ee.mem.Write(0x0200, 0xd9)
+
+			spent, err := ee.Emulate()
+			assert.Nil(t, err)
+			assert.Equal(t, uint16(0x0200), ee.pc, "jumps to called address")
+			assert.Equal(t, 17, spent, "takes as long as CALL")
+
+			_, err = ee.Emulate()
+			assert.Nil(t, err)
+			assert.Equal(t, uint16(0x0103), ee.pc, "returns to instruction after call")
+			assert.Equal(t, uint16(0x2400), ee.sp, "pops return address from the stack")
+		}
+	})
+
+	t.Run("when trapping undocumented opcodes", func(t *testing.T) {
+		ee := New(WithUndocumented(TrapUndocumented))
+		ee.Load(0, []byte{0x00, 0xcb, 0x00, 0x3e})
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err, "executes documented opcodes")
+
+		_, err = ee.Emulate()
+		assert.EqualError(t, err, "undocumented opcode 0xcb at 0x0001")
+		assert.Equal(t, uint16(1), ee.pc, "stops at undocumented opcode")
+		assert.Equal(t, uint64(4), ee.Cycles(), "does not count cycles of undocumented opcode")
+	})
+}
+
 func TestAddr(t *testing.T) {
 	var a uint8 = 0x01
 	var b uint8 = 0x02
@@ -338,3 +855,12 @@ func TestAddr(t *testing.T) {
 
 	assert.Equal(t, expected, addr(a, b))
 }
+
+func BenchmarkArithmetic(b *testing.B) {
+	ee := New()
+	ee.Load(0, []byte{0x80, 0x91, 0xa2, 0xb3, 0x3c, 0x05, 0x27, 0xc3, 0x00, 0x00}) // ADD B; SUB C; ANA D; ORA E; INR A; DCR B; DAA; JMP 0
+
+	for i := 0; i < b.N; i++ {
+		ee.Emulate()
+	}
+}
@@ -0,0 +1,201 @@
+package eighty_eighty
+
+import (
+	"bytes"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io/ioutil"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const (
+	fuzzProgramStart = 0x0100
+
+	// generatedDir holds single step cases generated from the reference model, not committed
+	generatedDir = "testdata/generated"
+	// generatedCases are generated for every opcode
+	generatedCases = 16
+)
+
+var generateSingleStep = flag.Bool("generate", false, "generate single step cases from the reference model and run them")
+
+// FuzzStep executes single instruction on random CPU state both in the emulator and in the reference
+// model and reports any divergence of registers, flags, memory or cycles
+func FuzzStep(f *testing.F) {
+	f.Add(uint8(0x80), uint8(0x00), uint8(0x00), uint8(0x6c), uint8(0x2e), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x02), uint16(0x2400), uint8(0x00))
+	f.Add(uint8(0x27), uint8(0x00), uint8(0x00), uint8(0x9b), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x13), uint16(0x0000), uint8(0x00))
+	f.Add(uint8(0x9e), uint8(0x00), uint8(0x00), uint8(0x10), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x20), uint8(0x00), uint8(0x03), uint16(0x0000), uint8(0x0f))
+	f.Add(uint8(0x35), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x01), uint8(0x01), uint8(0x02), uint16(0x0000), uint8(0x10))
+	f.Add(uint8(0x39), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0xff), uint8(0xff), uint8(0x02), uint16(0x0002), uint8(0x00))
+	f.Add(uint8(0xe6), uint8(0x0f), uint8(0x00), uint8(0xfc), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x03), uint16(0x0000), uint8(0x00))
+	f.Add(uint8(0x1f), uint8(0x00), uint8(0x00), uint8(0x6a), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x03), uint16(0x0000), uint8(0x00))
+	f.Add(uint8(0x0b), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x01), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x00), uint8(0x02), uint16(0x0000), uint8(0x00))
+
+	f.Fuzz(func(t *testing.T, op, lo, hi, a, b, c, d, e, h, l, flags uint8, sp uint16, m uint8) {
+		ee := New()
+		ee.a, ee.b, ee.c, ee.d, ee.e, ee.h, ee.l = a, b, c, d, e, h, l
+		ee.sp = sp
+		ee.pc = fuzzProgramStart
+		ee.cc.unpack(flags)
+		ee.mem.Write(addr(h, l), m)
+		ee.Load(fuzzProgramStart, []byte{op, lo, hi})
+
+		ref := &refCPU{
+			regs: [8]uint8{b, c, d, e, h, l, 0, a},
+			sp:   sp,
+			pc:   fuzzProgramStart,
+			s:    flags&0x80 != 0,
+			z:    flags&0x40 != 0,
+			ac:   flags&0x10 != 0,
+			p:    flags&0x04 != 0,
+			cy:   flags&0x01 != 0,
+			mem:  append([]uint8(nil), ee.mem.(RAM)...),
+		}
+		cycles := ref.step()
+
+		spent, err := ee.Emulate()
+		if err != nil {
+			t.Fatalf("opcode %#02x failed: %s", op, err.Error())
+		}
+		if spent != cycles {
+			t.Errorf("opcode %#02x: cycles expected %d, got %d", op, cycles, spent)
+		}
+
+		actual := []uint8{ee.b, ee.c, ee.d, ee.e, ee.h, ee.l, ee.cc.pack(), ee.a}
+		expected := ref.regs
+		expected[refM] = ref.flags()
+		for i, name := range []string{"b", "c", "d", "e", "h", "l", "flags", "a"} {
+			if actual[i] != expected[i] {
+				t.Errorf("opcode %#02x: %s expected %#02x, got %#02x", op, name, expected[i], actual[i])
+			}
+		}
+		if ee.sp != ref.sp {
+			t.Errorf("opcode %#02x: sp expected %#04x, got %#04x", op, ref.sp, ee.sp)
+		}
+		if ee.pc != ref.pc {
+			t.Errorf("opcode %#02x: pc expected %#04x, got %#04x", op, ref.pc, ee.pc)
+		}
+		if !bytes.Equal(ee.mem.(RAM), ref.mem) {
+			t.Errorf("opcode %#02x: memory differs", op)
+		}
+	})
+}
+
+// TestGeneratedSingleStep is a smoke test of single step fixtures and their runner: with -generate it
+// writes cases made by the reference model to testdata/generated and runs them. They come from the
+// same model FuzzStep uses, so they don't check conformance on their own.
+func TestGeneratedSingleStep(t *testing.T) {
+	if !*generateSingleStep {
+		t.Skip("run with -generate")
+	}
+
+	if err := os.MkdirAll(generatedDir, 0755); err != nil {
+		t.Fatalf("cant create %s: %s", generatedDir, err.Error())
+	}
+	for opCode := 0; opCode < 0x100; opCode++ {
+		if err := generateSingleStepFixture(uint8(opCode)); err != nil {
+			t.Fatalf("cant generate cases of %02x: %s", opCode, err.Error())
+		}
+	}
+
+	runSingleStep(t, generatedDir)
+}
+
+// generateSingleStepFixture writes cases of provided opcode made by running the reference model
+// on random state
+func generateSingleStepFixture(opCode uint8) error {
+	rnd := rand.New(rand.NewSource(int64(opCode)))
+	lines := make([]string, generatedCases)
+	for i := range lines {
+		raw, err := json.Marshal(newSingleStepCase(rnd, opCode, fmt.Sprintf("%02x ref %04d", opCode, i)))
+		if err != nil {
+			return err
+		}
+		lines[i] = "  " + string(raw)
+	}
+
+	path := filepath.Join(generatedDir, fmt.Sprintf("%02x.json", opCode))
+	return ioutil.WriteFile(path, []byte("[\n"+strings.Join(lines, ",\n")+"\n]\n"), 0644)
+}
+
+// newSingleStepCase runs the reference model on random registers and flags with random values in
+// every memory cell the instruction may access: operands, (BC), (DE), (HL), stack and addressed one
+func newSingleStepCase(rnd *rand.Rand, opCode uint8, name string) singleStepCase {
+	initial := singleStepState{
+		PC: uint16(rnd.Intn(0x10000)),
+		SP: uint16(rnd.Intn(0x10000)),
+		A:  uint8(rnd.Intn(0x100)),
+		B:  uint8(rnd.Intn(0x100)),
+		C:  uint8(rnd.Intn(0x100)),
+		D:  uint8(rnd.Intn(0x100)),
+		E:  uint8(rnd.Intn(0x100)),
+		F:  uint8(rnd.Intn(0x100))&0xd7 | 0x02,
+		H:  uint8(rnd.Intn(0x100)),
+		L:  uint8(rnd.Intn(0x100)),
+	}
+	lo, hi := uint8(rnd.Intn(0x100)), uint8(rnd.Intn(0x100))
+	address := addr(hi, lo)
+
+	cells := make(map[uint16]uint8)
+	for _, cell := range []uint16{
+		addr(initial.B, initial.C), addr(initial.D, initial.E), addr(initial.H, initial.L),
+		initial.SP, initial.SP + 1, address, address + 1,
+	} {
+		cells[cell] = uint8(rnd.Intn(0x100))
+	}
+	cells[initial.PC], cells[initial.PC+1], cells[initial.PC+2] = opCode, lo, hi // instruction wins
+
+	ref := &refCPU{
+		regs: [8]uint8{initial.B, initial.C, initial.D, initial.E, initial.H, initial.L, 0, initial.A},
+		sp:   initial.SP,
+		pc:   initial.PC,
+		s:    initial.F&0x80 != 0,
+		z:    initial.F&0x40 != 0,
+		ac:   initial.F&0x10 != 0,
+		p:    initial.F&0x04 != 0,
+		cy:   initial.F&0x01 != 0,
+		mem:  make([]uint8, 0x10000),
+	}
+	addresses := make([]int, 0, len(cells))
+	for cell, val := range cells {
+		ref.mem[cell] = val
+		addresses = append(addresses, int(cell))
+	}
+	sort.Ints(addresses)
+	for _, cell := range addresses {
+		initial.RAM = append(initial.RAM, [2]uint16{uint16(cell), uint16(cells[uint16(cell)])})
+	}
+
+	cycles := ref.step()
+
+	final := singleStepState{
+		PC: ref.pc,
+		SP: ref.sp,
+		A:  ref.regs[7],
+		B:  ref.regs[0],
+		C:  ref.regs[1],
+		D:  ref.regs[2],
+		E:  ref.regs[3],
+		F:  ref.flags(),
+		H:  ref.regs[4],
+		L:  ref.regs[5],
+	}
+	// pushing writes below the initial stack pointer, everything else writes cells set up above
+	for _, cell := range []uint16{initial.SP - 2, initial.SP - 1} {
+		if _, ok := cells[cell]; !ok {
+			addresses = append(addresses, int(cell))
+		}
+	}
+	sort.Ints(addresses)
+	for _, cell := range addresses {
+		final.RAM = append(final.RAM, [2]uint16{uint16(cell), uint16(ref.mem[cell])})
+	}
+
+	return singleStepCase{Name: name, Initial: initial, Final: final, Cycles: singleStepCycles(cycles)}
+}
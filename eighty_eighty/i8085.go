@@ -0,0 +1,224 @@
+package eighty_eighty
+
+import (
+	"github.com/piokaczm/8080-emulator/spec"
+)
+
+// Variant selects processor emulated by CPU
+type Variant int
+
+const (
+	// Intel8080 is the default variant
+	Intel8080 Variant = iota
+	// Intel8085 adds RIM and SIM instructions, TRAP and RST n.5 interrupt inputs, SID and SOD serial
+	// pins and its own instruction timings
+	Intel8085
+)
+
+var instructionSets = [...]*[256]spec.Opcode{
+	Intel8080: &spec.Intel8080,
+	Intel8085: &spec.Intel8085,
+}
+
+// WithVariant makes CPU emulate provided processor variant
+func WithVariant(variant Variant) Option {
+	return func(s *CPU) {
+		s.variant = variant
+	}
+}
+
+// WithSOD registers function called whenever 8085 changes level of its SOD pin
+func WithSOD(fn func(level bool)) Option {
+	return func(s *CPU) {
+		s.i85.sodFunc = fn
+	}
+}
+
+// InterruptLine is one of 8085 interrupt inputs besides INTR
+type InterruptLine int
+
+const (
+	// TRAP is non-maskable, it's latched on rising edge and needs to stay high until accepted
+	TRAP InterruptLine = iota
+	// RST55 is level sensitive and maskable
+	RST55
+	// RST65 is level sensitive and maskable
+	RST65
+	// RST75 is maskable, it's latched on rising edge until accepted or reset by SIM
+	RST75
+)
+
+// vectors holds addresses 8085 calls when accepting interrupt from given line
+var vectors = [...]uint16{
+	TRAP:  0x0024,
+	RST55: 0x002c,
+	RST65: 0x0034,
+	RST75: 0x003c,
+}
+
+// RIM and SIM accumulator bits
+const (
+	mask55 = 1 << 0
+	mask65 = 1 << 1
+	mask75 = 1 << 2
+	masks  = mask55 | mask65 | mask75
+
+	rimIE  = 1 << 3
+	rimI55 = 1 << 4
+	rimI65 = 1 << 5
+	rimI75 = 1 << 6
+	rimSID = 1 << 7
+
+	simMSE = 1 << 3 // mask set enable
+	simR75 = 1 << 4 // reset RST 7.5 latch
+	simSDE = 1 << 6 // serial data enable
+	simSOD = 1 << 7
+)
+
+// i8085 holds state of 8085 interrupt inputs and serial pins
+type i8085 struct {
+	lines   [4]bool // current levels of interrupt inputs
+	trap    bool    // TRAP rising edge waiting to be accepted
+	rst75   bool    // RST 7.5 rising edge waiting to be accepted
+	masks   uint8   // RST n.5 masks set by SIM
+	trapIE  uint8   // interrupts enable state from before TRAP, reported by the next RIM
+	trapped bool
+	sid     bool
+	sod     bool
+	sodFunc func(level bool)
+}
+
+// SetInterruptLine drives provided 8085 interrupt input to provided level; 8080 has no such inputs
+// and ignores them
+func (s *CPU) SetInterruptLine(line InterruptLine, level bool) {
+	if s.replaying() {
+		return
+	}
+
+	s.record(Event{Kind: EventInterruptLine, Port: uint8(line), Value: bit(level)})
+	s.setInterruptLine(line, level)
+}
+
+func (s *CPU) setInterruptLine(line InterruptLine, level bool) {
+	rising := level && !s.i85.lines[line]
+	s.i85.lines[line] = level
+
+	switch line {
+	case TRAP:
+		s.i85.trap = level && (s.i85.trap || rising)
+	case RST75:
+		s.i85.rst75 = s.i85.rst75 || rising
+	}
+}
+
+// SetSID drives 8085 serial input pin read by RIM
+func (s *CPU) SetSID(level bool) {
+	if s.replaying() {
+		return
+	}
+
+	s.record(Event{Kind: EventSID, Value: bit(level)})
+	s.i85.sid = level
+}
+
+// SOD returns level of 8085 serial output pin set by SIM
+func (s *CPU) SOD() bool {
+	return s.i85.sod
+}
+
+// vectoredInterrupt returns the highest priority 8085 interrupt input CPU accepts
+func (s *CPU) vectoredInterrupt() (InterruptLine, bool) {
+	if s.variant != Intel8085 {
+		return 0, false
+	}
+	if s.i85.trap {
+		return TRAP, true
+	}
+	if s.int_enable == 0 {
+		return 0, false
+	}
+
+	switch {
+	case s.i85.rst75 && s.i85.masks&mask75 == 0:
+		return RST75, true
+	case s.i85.lines[RST65] && s.i85.masks&mask65 == 0:
+		return RST65, true
+	case s.i85.lines[RST55] && s.i85.masks&mask55 == 0:
+		return RST55, true
+	}
+
+	return 0, false
+}
+
+// serveVectored acknowledges interrupt from provided input, disables further interrupts and calls
+// the input's vector
+func (s *CPU) serveVectored(line InterruptLine) (int, error) {
+	switch line {
+	case TRAP:
+		s.i85.trap = false
+		s.i85.trapIE = s.int_enable
+		s.i85.trapped = true
+	case RST75:
+		s.i85.rst75 = false
+	}
+
+	s.int_enable = 0
+	s.halted = false
+	s.push16(s.pc)
+	s.pc = vectors[line]
+
+	s.cycles += vectoredCycles
+	return vectoredCycles, nil
+}
+
+// rim reads interrupt masks, interrupts enable state, pending RST n.5 interrupts and SID pin into
+// accumulator; right after TRAP it reports interrupts enable state from before it
+func (s *CPU) rim() {
+	ie := s.int_enable
+	if s.i85.trapped {
+		ie = s.i85.trapIE
+		s.i85.trapped = false
+	}
+
+	s.a = s.i85.masks
+	if ie == 1 {
+		s.a |= rimIE
+	}
+	if s.i85.lines[RST55] {
+		s.a |= rimI55
+	}
+	if s.i85.lines[RST65] {
+		s.a |= rimI65
+	}
+	if s.i85.rst75 {
+		s.a |= rimI75
+	}
+	if s.i85.sid {
+		s.a |= rimSID
+	}
+}
+
+// sim sets interrupt masks when MSE bit is set, resets RST 7.5 latch when R7.5 bit is set and
+// outputs SOD bit when SDE bit is set
+func (s *CPU) sim() {
+	if s.a&simMSE != 0 {
+		s.i85.masks = s.a & masks
+	}
+	if s.a&simR75 != 0 {
+		s.i85.rst75 = false
+	}
+	if s.a&simSDE != 0 {
+		s.setSOD(s.a&simSOD != 0)
+	}
+}
+
+func (s *CPU) setSOD(level bool) {
+	if level == s.i85.sod {
+		return
+	}
+
+	s.i85.sod = level
+	if s.i85.sodFunc != nil {
+		s.i85.sodFunc(level)
+	}
+}
@@ -0,0 +1,283 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRIM(t *testing.T) {
+	t.Run("when 8080", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0x20})
+		ee.a = 0x3e
+		ee.SetSID(true)
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0x3e), ee.a, "executes NOP")
+	})
+
+	t.Run("when 8085", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.Load(0, []byte{0x20})
+		ee.int_enable = 1
+		ee.i85.masks = mask65
+		ee.SetInterruptLine(RST55, true)
+		ee.SetInterruptLine(RST75, true)
+		ee.SetSID(true)
+
+		spent, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(0xda), ee.a, "reads SID, pending interrupts, interrupts enable state and masks")
+		assert.Equal(t, 4, spent, "takes 8085 cycles")
+	})
+
+	t.Run("when TRAP was accepted", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.mem.Write(0x0024, 0x20)
+		ee.sp = 0x2400
+		ee.int_enable = 1
+
+		ee.SetInterruptLine(TRAP, true)
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.False(t, ee.InterruptsEnabled(), "disables interrupts")
+
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(rimIE), ee.a, "reports interrupts enable state from before TRAP")
+	})
+}
+
+func TestSIM(t *testing.T) {
+	t.Run("when 8080", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0x30})
+		ee.a = simMSE | mask75
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Zero(t, ee.i85.masks, "executes NOP")
+	})
+
+	t.Run("setting masks", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.Load(0, []byte{0x30, 0x30})
+
+		ee.a = simMSE | mask55 | mask75
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(mask55|mask75), ee.i85.masks, "sets masks when MSE is set")
+
+		ee.a = mask65
+		_, err = ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(mask55|mask75), ee.i85.masks, "keeps masks when MSE is not set")
+	})
+
+	t.Run("resetting RST 7.5", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.Load(0, []byte{0x30})
+		ee.SetInterruptLine(RST75, true)
+
+		ee.a = simR75
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.False(t, ee.i85.rst75, "resets RST 7.5 latch")
+	})
+
+	t.Run("driving SOD", func(t *testing.T) {
+		var levels []bool
+		ee := New(WithVariant(Intel8085), WithSOD(func(level bool) { levels = append(levels, level) }))
+		ee.Load(0, []byte{0x30, 0x30, 0x30, 0x30})
+
+		for _, val := range []uint8{simSOD, simSDE | simSOD, simSDE | simSOD, simSDE} {
+			ee.a = val
+			_, err := ee.Emulate()
+			assert.Nil(t, err)
+		}
+		assert.False(t, ee.SOD(), "sets SOD from the last SIM")
+		assert.Equal(t, []bool{true, false}, levels, "reports SOD changes only when SDE is set")
+	})
+}
+
+func TestVectoredInterrupts(t *testing.T) {
+	testCases := []struct {
+		line   InterruptLine
+		vector uint16
+	}{
+		{TRAP, 0x0024},
+		{RST55, 0x002c},
+		{RST65, 0x0034},
+		{RST75, 0x003c},
+	}
+
+	for _, testCase := range testCases {
+		ee := New(WithVariant(Intel8085))
+		ee.sp = 0x2400
+		ee.pc = 0x0123
+		ee.int_enable = 1
+
+		ee.SetInterruptLine(testCase.line, true)
+		spent, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, testCase.vector, ee.pc, "calls the line's vector")
+		assert.Equal(t, uint16(0x0123), ee.pop16(), "pushes address of interrupted instruction")
+		assert.Equal(t, vectoredCycles, spent, "returns number of cycles")
+	}
+
+	t.Run("when 8080", func(t *testing.T) {
+		ee := New()
+		ee.int_enable = 1
+
+		ee.SetInterruptLine(TRAP, true)
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(1), ee.pc, "ignores 8085 inputs")
+	})
+
+	t.Run("when interrupts are disabled", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.sp = 0x2400
+
+		ee.SetInterruptLine(RST55, true)
+		ee.SetInterruptLine(RST75, true)
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(1), ee.pc, "ignores maskable inputs")
+
+		ee.SetInterruptLine(TRAP, true)
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0024), ee.pc, "accepts TRAP")
+	})
+
+	t.Run("when inputs are masked", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.sp = 0x2400
+		ee.int_enable = 1
+		ee.i85.masks = mask75 | mask65
+
+		ee.SetInterruptLine(RST75, true)
+		ee.SetInterruptLine(RST65, true)
+		ee.SetInterruptLine(RST55, true)
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x002c), ee.pc, "accepts the highest priority unmasked input")
+		assert.True(t, ee.i85.rst75, "keeps masked RST 7.5 latched")
+	})
+
+	t.Run("when INTR is requested too", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.sp = 0x2400
+		ee.int_enable = 1
+
+		ee.Interrupt(RST(1))
+		ee.SetInterruptLine(RST55, true)
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x002c), ee.pc, "gives priority to 8085 inputs")
+		assert.NotNil(t, ee.intRequest, "keeps INTR pending")
+	})
+
+	t.Run("when edge triggered input pulses", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.sp = 0x2400
+		ee.int_enable = 1
+
+		ee.SetInterruptLine(TRAP, true)
+		ee.SetInterruptLine(TRAP, false)
+		ee.SetInterruptLine(RST75, true)
+		ee.SetInterruptLine(RST75, false)
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x003c), ee.pc, "drops TRAP that went low and keeps RST 7.5 latched")
+	})
+
+	t.Run("when CPU is halted", func(t *testing.T) {
+		ee := New(WithVariant(Intel8085))
+		ee.Load(0, []byte{0xfb, 0x76}) // EI; HLT
+		ee.sp = 0x2400
+
+		err := ee.Run()
+		assert.Nil(t, err)
+		assert.True(t, ee.Halted())
+
+		ee.SetInterruptLine(RST65, true)
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.False(t, ee.Halted(), "wakes up")
+		assert.Equal(t, uint16(0x0034), ee.pc, "calls the line's vector")
+	})
+}
+
+func TestCycles8085(t *testing.T) {
+	testCases := []struct {
+		name     string
+		program  []byte
+		z        uint8
+		expected int
+	}{
+		{"MOV B,C", []byte{0x41}, 0, 4},
+		{"INX B", []byte{0x03}, 0, 6},
+		{"HLT", []byte{0x76}, 0, 5},
+		{"JZ adr when not taken", []byte{0xca, 0x00, 0x20}, 0, 7},
+		{"JZ adr when taken", []byte{0xca, 0x00, 0x20}, 1, 10},
+		{"CZ adr when not taken", []byte{0xcc, 0x00, 0x20}, 0, 9},
+		{"CZ adr when taken", []byte{0xcc, 0x00, 0x20}, 1, 18},
+		{"RZ when not taken", []byte{0xc8}, 0, 6},
+		{"RZ when taken", []byte{0xc8}, 1, 12},
+		{"PUSH B", []byte{0xc5}, 0, 12},
+		{"XTHL", []byte{0xe3}, 0, 16},
+		{"RST 1", []byte{0xcf}, 0, 12},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ee := New(WithVariant(Intel8085))
+			ee.sp = 0x2400
+			ee.cc.z = testCase.z
+			ee.Load(0, testCase.program)
+
+			spent, err := ee.Emulate()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, spent, "returns number of cycles")
+		})
+	}
+}
+
+func TestANA8085(t *testing.T) {
+	testCases := []struct {
+		name    string
+		program []byte
+		variant Variant
+		ac      uint8
+	}{
+		{"ANA when 8080", []byte{0xa0}, Intel8080, 0},
+		{"ANA when 8085", []byte{0xa0}, Intel8085, 1},
+		{"ANI when 8080", []byte{0xe6, 0x30}, Intel8080, 0},
+		{"ANI when 8085", []byte{0xe6, 0x30}, Intel8085, 1},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ee := New(WithVariant(testCase.variant))
+			ee.Load(0, testCase.program)
+			ee.a, ee.b = 0xf1, 0x30 // bits 3 of both operands are reset
+
+			_, err := ee.Emulate()
+			assert.Nil(t, err)
+			assert.Equal(t, uint8(0x30), ee.a, "stores result in accumulator")
+			assert.Equal(t, testCase.ac, ee.cc.ac, "sets AC flag")
+		})
+	}
+}
+
+func TestUndocumented8085(t *testing.T) {
+	ee := New(WithVariant(Intel8085))
+	ee.Load(0, []byte{0xcb, 0x00, 0x3e})
+
+	_, err := ee.Emulate()
+	assert.NotNil(t, err, "does not alias 8080 undocumented opcodes")
+}
@@ -0,0 +1,78 @@
+package eighty_eighty
+
+import (
+	"fmt"
+)
+
+// RST returns opcode of RST n instruction, the usual instruction interrupting devices put on the data bus
+func RST(n uint8) uint8 {
+	return 0xc7 | (n&0x07)<<3
+}
+
+// Interrupt requests an interrupt with provided instruction placed on the data bus. The request stays
+// pending, like a device holding INT line, until CPU accepts it or it gets cleared; CPU accepts it
+// at instruction boundary when interrupts are enabled, which also wakes it up from HLT.
+func (s *CPU) Interrupt(instruction ...uint8) {
+	if s.replaying() {
+		return
+	}
+
+	s.record(Event{Kind: EventInterrupt, Data: append([]uint8(nil), instruction...)})
+	s.intRequest = instruction
+}
+
+// ClearInterrupt drops pending interrupt request
+func (s *CPU) ClearInterrupt() {
+	if s.replaying() {
+		return
+	}
+
+	s.record(Event{Kind: EventClearInterrupt})
+	s.intRequest = nil
+}
+
+// InterruptsEnabled reports whether CPU accepts interrupts
+func (s *CPU) InterruptsEnabled() bool {
+	return s.int_enable == 1
+}
+
+// interruptPending reports whether there's a request CPU accepts
+func (s *CPU) interruptPending() bool {
+	if _, ok := s.vectoredInterrupt(); ok {
+		return true
+	}
+
+	return s.int_enable == 1 && s.intRequest != nil
+}
+
+// ei enables interrupts starting after the next instruction
+func (s *CPU) ei() {
+	s.int_enable = 1
+	s.intDelay = true
+}
+
+// serveInterrupt acknowledges pending request, giving 8085 inputs priority over INTR, disables further
+// interrupts and executes instruction from the data bus; its bytes don't advance pc, so RST and CALL
+// push address of interrupted instruction
+func (s *CPU) serveInterrupt() (int, error) {
+	if line, ok := s.vectoredInterrupt(); ok {
+		return s.serveVectored(line)
+	}
+
+	instruction := s.intRequest
+	if len(instruction) == 0 {
+		return 0, fmt.Errorf("interrupt with no instruction on the data bus")
+	}
+	if s.unsupported(instruction[0]) {
+		return 0, fmt.Errorf("undocumented opcode %#02x on the data bus", instruction[0]) // request stays pending
+	}
+
+	s.intRequest = nil
+	s.int_enable = 0
+	s.halted = false
+
+	s.injected = instruction
+	defer func() { s.injected = nil }()
+
+	return s.Emulate()
+}
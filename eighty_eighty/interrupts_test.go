@@ -0,0 +1,116 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRSTOpcode(t *testing.T) {
+	assert.Equal(t, uint8(0xc7), RST(0))
+	assert.Equal(t, uint8(0xcf), RST(1))
+	assert.Equal(t, uint8(0xff), RST(7))
+}
+
+func TestInterrupt(t *testing.T) {
+	t.Run("when interrupts are enabled", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2400
+		ee.pc = 0x0123
+		ee.int_enable = 1
+
+		ee.Interrupt(RST(2))
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0010), ee.pc, "jumps to restart routine")
+		assert.Equal(t, uint16(0x0123), addr(ee.mem.Read(0x23ff), ee.mem.Read(0x23fe)), "pushes address of interrupted instruction")
+		assert.False(t, ee.InterruptsEnabled(), "disables interrupts")
+		assert.Nil(t, ee.intRequest, "acknowledges request")
+	})
+
+	t.Run("when interrupts are disabled", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0x00, 0xfb, 0x00, 0x00}) // NOP; EI; NOP; NOP
+		ee.sp = 0x2400
+
+		ee.Interrupt(RST(1))
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(1), ee.pc, "ignores interrupt and executes instruction")
+
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(2), ee.pc, "executes EI")
+
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(3), ee.pc, "executes one more instruction after EI")
+
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0008), ee.pc, "serves request kept pending")
+		assert.Equal(t, uint16(3), addr(ee.mem.Read(0x23ff), ee.mem.Read(0x23fe)), "pushes address of interrupted instruction")
+	})
+
+	t.Run("when request is cleared", func(t *testing.T) {
+		ee := New()
+		ee.int_enable = 1
+
+		ee.Interrupt(RST(1))
+		ee.ClearInterrupt()
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(1), ee.pc, "executes instruction from memory")
+	})
+
+	t.Run("when trapped undocumented opcode is on the data bus", func(t *testing.T) {
+		ee := New(WithUndocumented(TrapUndocumented))
+		ee.sp = 0x2400
+		ee.pc = 0x0100
+		ee.int_enable = 1
+
+		ee.Interrupt(0xcb, 0x00, 0x20)
+		_, err := ee.Step()
+		assert.EqualError(t, err, "undocumented opcode 0xcb on the data bus")
+		assert.Equal(t, uint16(0x0100), ee.pc, "keeps pc")
+		assert.True(t, ee.InterruptsEnabled(), "keeps interrupts enabled")
+		assert.NotNil(t, ee.intRequest, "keeps request pending")
+	})
+
+	t.Run("when multi byte instruction is on the data bus", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0x2400
+		ee.pc = 0x0123
+		ee.int_enable = 1
+
+		ee.Interrupt(0xcd, 0x00, 0x30) // CALL 0x3000
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x3000), ee.pc, "calls provided address")
+		assert.Equal(t, uint16(0x0123), addr(ee.mem.Read(0x23ff), ee.mem.Read(0x23fe)), "pushes address of interrupted instruction")
+	})
+
+	t.Run("when CPU is halted", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0xfb, 0x76}) // EI; HLT
+		ee.sp = 0x2400
+
+		_, err := ee.Step()
+		assert.Nil(t, err)
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.True(t, ee.halted, "halts")
+
+		spent, err := ee.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(2), ee.pc, "stays halted without interrupt")
+		assert.Equal(t, haltedCycles, spent, "keeps consuming cycles")
+
+		ee.Interrupt(RST(7))
+		_, err = ee.Step()
+		assert.Nil(t, err)
+		assert.False(t, ee.halted, "wakes up")
+		assert.Equal(t, uint16(0x0038), ee.pc, "jumps to restart routine")
+		assert.Equal(t, uint16(2), addr(ee.mem.Read(0x23ff), ee.mem.Read(0x23fe)), "pushes address following HLT")
+	})
+}
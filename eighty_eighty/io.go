@@ -0,0 +1,71 @@
+package eighty_eighty
+
+// IOHandler is a peripheral attached to CPU ports; IN reads from it and OUT writes to it
+type IOHandler interface {
+	In(port uint8) uint8
+	Out(port uint8, val uint8)
+}
+
+// PortFuncs is an IOHandler backed by provided functions; missing InFunc reads open bus,
+// missing OutFunc ignores written values
+type PortFuncs struct {
+	InFunc  func(port uint8) uint8
+	OutFunc func(port uint8, val uint8)
+}
+
+// In calls InFunc with provided port
+func (pf PortFuncs) In(port uint8) uint8 {
+	if pf.InFunc == nil {
+		return openBus
+	}
+
+	return pf.InFunc(port)
+}
+
+// Out calls OutFunc with provided port and value
+func (pf PortFuncs) Out(port uint8, val uint8) {
+	if pf.OutFunc != nil {
+		pf.OutFunc(port, val)
+	}
+}
+
+// WithPorts attaches provided handler to ports from first to last inclusive
+func WithPorts(first, last uint8, handler IOHandler) Option {
+	return func(s *CPU) {
+		s.AttachPorts(first, last, handler)
+	}
+}
+
+// AttachPort attaches provided handler to a single port; nil detaches current one
+func (s *CPU) AttachPort(port uint8, handler IOHandler) {
+	s.ports[port] = handler
+}
+
+// AttachPorts attaches provided handler to ports from first to last inclusive
+func (s *CPU) AttachPorts(first, last uint8, handler IOHandler) {
+	for port := int(first); port <= int(last); port++ {
+		s.ports[port] = handler
+	}
+}
+
+// in loads accumulator from provided port; ports without handler read open bus. While replaying
+// the handler is still called, but the value comes from the recording.
+func (s *CPU) in(port uint8) {
+	val := uint8(openBus)
+	if handler := s.ports[port]; handler != nil {
+		val = handler.In(port)
+	}
+	if s.replaying() {
+		val, s.replayErr = s.replayIn(port)
+	}
+
+	s.record(Event{Kind: EventIn, Port: port, Value: val})
+	s.a = val
+}
+
+// out sends accumulator to provided port
+func (s *CPU) out(port uint8) {
+	if handler := s.ports[port]; handler != nil {
+		handler.Out(port, s.a)
+	}
+}
@@ -0,0 +1,83 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type portLog struct {
+	in  []uint8
+	out []uint8
+}
+
+func (pl *portLog) In(port uint8) uint8 {
+	pl.in = append(pl.in, port)
+	return port + 1
+}
+
+func (pl *portLog) Out(port uint8, val uint8) {
+	pl.out = append(pl.out, port, val)
+}
+
+func TestIN(t *testing.T) {
+	t.Run("when port has handler", func(t *testing.T) {
+		device := &portLog{}
+		ee := New(WithPorts(0x10, 0x1f, device))
+		ee.Load(0, []byte{0xdb, 0x12})
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, []uint8{0x12}, device.in, "reads from handler with port number")
+		assert.Equal(t, uint8(0x13), ee.a, "loads accumulator with handler's value")
+		assert.Equal(t, uint16(2), ee.pc, "increments pc by two")
+	})
+
+	t.Run("when port has no handler", func(t *testing.T) {
+		ee := New()
+		ee.Load(0, []byte{0xdb, 0x12})
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, uint8(openBus), ee.a, "reads open bus")
+	})
+}
+
+func TestOUT(t *testing.T) {
+	t.Run("when port has handler", func(t *testing.T) {
+		device := &portLog{}
+		ee := New()
+		ee.AttachPort(0x03, device)
+		ee.Load(0, []byte{0xd3, 0x03})
+		ee.a = 0x1f
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Equal(t, []uint8{0x03, 0x1f}, device.out, "writes accumulator to handler with port number")
+		assert.Equal(t, uint16(2), ee.pc, "increments pc by two")
+	})
+
+	t.Run("when handler is detached", func(t *testing.T) {
+		device := &portLog{}
+		ee := New(WithPorts(0x00, 0xff, device))
+		ee.AttachPort(0x03, nil)
+		ee.Load(0, []byte{0xd3, 0x03})
+
+		_, err := ee.Emulate()
+		assert.Nil(t, err)
+		assert.Empty(t, device.out, "does not write to any handler")
+	})
+}
+
+func TestPortFuncs(t *testing.T) {
+	var written uint8
+	pf := PortFuncs{
+		InFunc:  func(port uint8) uint8 { return 0x42 },
+		OutFunc: func(port uint8, val uint8) { written = val },
+	}
+
+	pf.Out(0x01, 0x1f)
+	assert.Equal(t, uint8(0x42), pf.In(0x01), "reads through InFunc")
+	assert.Equal(t, uint8(0x1f), written, "writes through OutFunc")
+	assert.Equal(t, uint8(openBus), PortFuncs{}.In(0x01), "reads open bus without InFunc")
+}
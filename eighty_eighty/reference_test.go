@@ -0,0 +1,350 @@
+package eighty_eighty
+
+// refCPU is a deliberately simple 8080 model used as a reference when fuzzing the emulator and
+// generating single step fixtures. It decodes instructions from opcode bit fields, derives flags
+// from their definitions using plain int arithmetic and counts cycles after Intel's manual, sharing
+// no code with the emulator nor the spec. Interrupts aren't modelled, EI and DI do nothing, HLT only
+// moves to the next instruction and IN reads open bus since no ports are attached.
+type refCPU struct {
+	regs            [8]uint8 // indexed with 8080 register encoding: B C D E H L (M) A
+	sp              uint16
+	pc              uint16
+	s, z, ac, p, cy bool
+	mem             []uint8
+}
+
+const refM = 6
+
+func (r *refCPU) hl() uint16 {
+	return uint16(r.regs[4])<<8 | uint16(r.regs[5])
+}
+
+func (r *refCPU) get(code uint8) uint8 {
+	if code == refM {
+		return r.mem[r.hl()]
+	}
+	return r.regs[code]
+}
+
+func (r *refCPU) set(code uint8, val uint8) {
+	if code == refM {
+		r.mem[r.hl()] = val
+	} else {
+		r.regs[code] = val
+	}
+}
+
+// pair uses 8080 register pair encoding: BC DE HL SP
+func (r *refCPU) pair(code uint8) uint16 {
+	if code == 3 {
+		return r.sp
+	}
+	return uint16(r.regs[code*2])<<8 | uint16(r.regs[code*2+1])
+}
+
+func (r *refCPU) setPair(code uint8, val uint16) {
+	if code == 3 {
+		r.sp = val
+		return
+	}
+	r.regs[code*2] = uint8(val >> 8)
+	r.regs[code*2+1] = uint8(val)
+}
+
+func (r *refCPU) flags() uint8 {
+	var f uint8 = 0x02
+	for bit, set := range map[uint8]bool{7: r.s, 6: r.z, 4: r.ac, 2: r.p, 0: r.cy} {
+		if set {
+			f |= 1 << bit
+		}
+	}
+	return f
+}
+
+func (r *refCPU) setZSP(val uint8) {
+	r.z = val == 0
+	r.s = val >= 0x80
+
+	ones := 0
+	for i := 0; i < 8; i++ {
+		if val&(1<<i) != 0 {
+			ones++
+		}
+	}
+	r.p = ones%2 == 0
+}
+
+func (r *refCPU) alu(operation uint8, val uint8) {
+	a := int(r.regs[7])
+	v := int(val)
+	carry := 0
+	if r.cy {
+		carry = 1
+	}
+
+	var result int
+	switch operation {
+	case 0, 1: // ADD, ADC
+		if operation == 0 {
+			carry = 0
+		}
+		result = a + v + carry
+		r.ac = a%16+v%16+carry > 15
+		r.cy = result > 255
+	case 2, 3, 7: // SUB, SBB, CMP
+		if operation != 3 {
+			carry = 0
+		}
+		result = a - v - carry
+		r.ac = a%16-v%16-carry >= 0 // no borrow into bit 4
+		r.cy = result < 0
+	case 4: // ANA
+		result = a & v
+		r.ac = (a|v)&8 != 0
+		r.cy = false
+	case 5: // XRA
+		result = a ^ v
+		r.ac, r.cy = false, false
+	case 6: // ORA
+		result = a | v
+		r.ac, r.cy = false, false
+	}
+
+	r.setZSP(uint8(result))
+	if operation != 7 {
+		r.regs[7] = uint8(result)
+	}
+}
+
+// daa follows two steps described in Intel 8080 manual
+func (r *refCPU) daa() {
+	a := int(r.regs[7])
+	ac, cy := false, r.cy
+
+	if a%16 > 9 || r.ac {
+		ac = a%16+6 > 15
+		a += 6
+	}
+	if a/16%16 > 9 || cy || a > 255 {
+		a += 0x60
+		cy = true
+	}
+
+	r.regs[7] = uint8(a)
+	r.setZSP(uint8(a))
+	r.ac, r.cy = ac, cy
+}
+
+func (r *refCPU) read16(address uint16) uint16 {
+	return uint16(r.mem[address+1])<<8 | uint16(r.mem[address])
+}
+
+func (r *refCPU) write16(address uint16, val uint16) {
+	r.mem[address] = uint8(val)
+	r.mem[address+1] = uint8(val >> 8)
+}
+
+func (r *refCPU) push(val uint16) {
+	r.sp -= 2
+	r.write16(r.sp, val)
+}
+
+func (r *refCPU) pop() uint16 {
+	val := r.read16(r.sp)
+	r.sp += 2
+	return val
+}
+
+// condition uses 8080 condition encoding: NZ Z NC C PO PE P M
+func (r *refCPU) condition(code uint8) bool {
+	flag := [4]bool{r.z, r.cy, r.p, r.s}[code/2]
+	return flag == (code%2 == 1)
+}
+
+// step executes instruction at pc and returns number of cycles it took
+func (r *refCPU) step() int {
+	op := r.mem[r.pc]
+	lo, hi := r.mem[r.pc+1], r.mem[r.pc+2]
+	address := uint16(hi)<<8 | uint16(lo)
+	x, y, z := op>>6, op>>3&7, op&7
+	size := uint16(1)
+	cycles := 4
+
+	switch {
+	case x == 0 && z == 0: // NOP, undocumented aliases included
+	case x == 1 && op == 0x76: // HLT
+		cycles = 7
+	case x == 1: // MOV
+		r.set(y, r.get(z))
+		cycles = 5
+		if y == refM || z == refM {
+			cycles = 7
+		}
+	case x == 2: // arithmetic and logic on registers
+		r.alu(y, r.get(z))
+		if z == refM {
+			cycles = 7
+		}
+	case x == 3 && z == 6: // arithmetic and logic on immediate
+		r.alu(y, lo)
+		size, cycles = 2, 7
+	case x == 0 && z == 4: // INR
+		val := r.get(y)
+		r.set(y, val+1)
+		r.setZSP(val + 1)
+		r.ac = val%16 == 15
+		cycles = 5
+		if y == refM {
+			cycles = 10
+		}
+	case x == 0 && z == 5: // DCR
+		val := r.get(y)
+		r.set(y, val-1)
+		r.setZSP(val - 1)
+		r.ac = val%16 != 0
+		cycles = 5
+		if y == refM {
+			cycles = 10
+		}
+	case x == 0 && z == 6: // MVI
+		r.set(y, lo)
+		size, cycles = 2, 7
+		if y == refM {
+			cycles = 10
+		}
+	case x == 0 && z == 1 && y%2 == 0: // LXI
+		r.setPair(y/2, address)
+		size, cycles = 3, 10
+	case x == 0 && z == 1: // DAD
+		sum := int(r.hl()) + int(r.pair(y/2))
+		r.setPair(2, uint16(sum))
+		r.cy = sum > 0xffff
+		cycles = 10
+	case x == 0 && z == 2 && y < 4 && y%2 == 0: // STAX
+		r.mem[r.pair(y/2)] = r.regs[7]
+		cycles = 7
+	case x == 0 && z == 2 && y < 4: // LDAX
+		r.regs[7] = r.mem[r.pair(y/2)]
+		cycles = 7
+	case op == 0x22: // SHLD
+		r.write16(address, r.hl())
+		size, cycles = 3, 16
+	case op == 0x2a: // LHLD
+		r.setPair(2, r.read16(address))
+		size, cycles = 3, 16
+	case op == 0x32: // STA
+		r.mem[address] = r.regs[7]
+		size, cycles = 3, 13
+	case op == 0x3a: // LDA
+		r.regs[7] = r.mem[address]
+		size, cycles = 3, 13
+	case x == 0 && z == 3 && y%2 == 0: // INX
+		r.setPair(y/2, r.pair(y/2)+1)
+		cycles = 5
+	case x == 0 && z == 3: // DCX
+		r.setPair(y/2, r.pair(y/2)-1)
+		cycles = 5
+	case op == 0x07: // RLC
+		a := r.regs[7]
+		r.cy = a >= 0x80
+		r.regs[7] = a*2 + a/0x80
+	case op == 0x0f: // RRC
+		a := r.regs[7]
+		r.cy = a%2 == 1
+		r.regs[7] = a/2 + a%2*0x80
+	case op == 0x17: // RAL
+		a, carry := r.regs[7], uint8(0)
+		if r.cy {
+			carry = 1
+		}
+		r.cy = a >= 0x80
+		r.regs[7] = a*2 + carry
+	case op == 0x1f: // RAR
+		a, carry := r.regs[7], uint8(0)
+		if r.cy {
+			carry = 0x80
+		}
+		r.cy = a%2 == 1
+		r.regs[7] = a/2 + carry
+	case op == 0x27: // DAA
+		r.daa()
+	case op == 0x2f: // CMA
+		r.regs[7] = 0xff - r.regs[7]
+	case op == 0x37: // STC
+		r.cy = true
+	case op == 0x3f: // CMC
+		r.cy = !r.cy
+	case x == 3 && z == 0: // conditional RET
+		cycles = 5
+		if r.condition(y) {
+			r.pc = r.pop()
+			return 11
+		}
+	case op == 0xc9 || op == 0xd9: // RET, undocumented alias included
+		r.pc = r.pop()
+		return 10
+	case op == 0xf1: // POP PSW
+		val := r.pop()
+		f := uint8(val)
+		r.s, r.z, r.ac, r.p, r.cy = f&0x80 != 0, f&0x40 != 0, f&0x10 != 0, f&0x04 != 0, f&0x01 != 0
+		r.regs[7] = uint8(val >> 8)
+		cycles = 10
+	case x == 3 && z == 1 && y%2 == 0: // POP
+		r.setPair(y/2, r.pop())
+		cycles = 10
+	case op == 0xe9: // PCHL
+		r.pc = r.hl()
+		return 5
+	case op == 0xf9: // SPHL
+		r.sp = r.hl()
+		cycles = 5
+	case x == 3 && z == 2: // conditional JMP
+		size, cycles = 3, 10
+		if r.condition(y) {
+			r.pc = address
+			return cycles
+		}
+	case op == 0xc3 || op == 0xcb: // JMP, undocumented alias included
+		r.pc = address
+		return 10
+	case op == 0xd3: // OUT
+		size, cycles = 2, 10
+	case op == 0xdb: // IN
+		r.regs[7] = 0xff
+		size, cycles = 2, 10
+	case op == 0xe3: // XTHL
+		top := r.read16(r.sp)
+		r.write16(r.sp, r.hl())
+		r.setPair(2, top)
+		cycles = 18
+	case op == 0xeb: // XCHG
+		de, hl := r.pair(1), r.pair(2)
+		r.setPair(1, hl)
+		r.setPair(2, de)
+	case op == 0xf3 || op == 0xfb: // DI, EI
+	case x == 3 && z == 4: // conditional CALL
+		size, cycles = 3, 11
+		if r.condition(y) {
+			r.push(r.pc + size)
+			r.pc = address
+			return 17
+		}
+	case op == 0xf5: // PUSH PSW
+		r.push(uint16(r.regs[7])<<8 | uint16(r.flags()))
+		cycles = 11
+	case x == 3 && z == 5 && y%2 == 0: // PUSH
+		r.push(r.pair(y / 2))
+		cycles = 11
+	case x == 3 && z == 5: // CALL, undocumented aliases included
+		r.push(r.pc + 3)
+		r.pc = address
+		return 17
+	case x == 3 && z == 7: // RST
+		r.push(r.pc + 1)
+		r.pc = uint16(y) * 8
+		return 11
+	}
+
+	r.pc += size
+	return cycles
+}
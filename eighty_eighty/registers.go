@@ -0,0 +1,84 @@
+package eighty_eighty
+
+// register returns value of provided register; m reads memory at address stored in hl
+func (s *CPU) register(reg int) uint8 {
+	switch reg {
+	case a:
+		return s.a
+	case b:
+		return s.b
+	case c:
+		return s.c
+	case d:
+		return s.d
+	case e:
+		return s.e
+	case h:
+		return s.h
+	case l:
+		return s.l
+	case m:
+		return s.mem.Read(s.pair(hl))
+	}
+
+	return 0
+}
+
+// setRegister sets value of provided register; m writes memory at address stored in hl
+func (s *CPU) setRegister(reg int, val uint8) {
+	switch reg {
+	case a:
+		s.a = val
+	case b:
+		s.b = val
+	case c:
+		s.c = val
+	case d:
+		s.d = val
+	case e:
+		s.e = val
+	case h:
+		s.h = val
+	case l:
+		s.l = val
+	case m:
+		s.mem.Write(s.pair(hl), val)
+	}
+}
+
+// pair returns 16bit value of provided registers pair; first register of the pair holds high byte
+func (s *CPU) pair(regPair int) uint16 {
+	switch regPair {
+	case bc:
+		return addr(s.b, s.c)
+	case de:
+		return addr(s.d, s.e)
+	case hl:
+		return addr(s.h, s.l)
+	case sp:
+		return s.sp
+	case psw:
+		return addr(s.a, s.cc.pack())
+	}
+
+	return 0
+}
+
+// setPair splits 16bit value between registers of provided pair; high byte goes to the first one
+func (s *CPU) setPair(regPair int, val uint16) {
+	hi, lo := uint8(val>>8), uint8(val)
+
+	switch regPair {
+	case bc:
+		s.b, s.c = hi, lo
+	case de:
+		s.d, s.e = hi, lo
+	case hl:
+		s.h, s.l = hi, lo
+	case sp:
+		s.sp = val
+	case psw:
+		s.a = hi
+		s.cc.unpack(lo)
+	}
+}
@@ -0,0 +1,89 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRegister(t *testing.T) {
+	t.Run("when memory reference", func(t *testing.T) {
+		ee := New()
+		ee.h, ee.l = 0x20, 0x10
+
+		ee.setRegister(m, 0x1f)
+		assert.Equal(t, uint8(0x1f), ee.mem.Read(0x2010), "writes memory addressed by hl")
+		assert.Equal(t, uint8(0x1f), ee.register(m), "reads memory addressed by hl")
+	})
+}
+
+func TestPair(t *testing.T) {
+	ee := New()
+
+	pairs := []struct {
+		registersPair int
+		hi            *uint8
+		lo            *uint8
+	}{
+		{bc, &ee.b, &ee.c},
+		{de, &ee.d, &ee.e},
+		{hl, &ee.h, &ee.l},
+	}
+
+	for _, testCase := range pairs {
+		ee.setPair(testCase.registersPair, 0x1234)
+		assert.Equal(t, uint8(0x12), *testCase.hi, "stores high byte in the first register")
+		assert.Equal(t, uint8(0x34), *testCase.lo, "stores low byte in the second register")
+		assert.Equal(t, uint16(0x1234), ee.pair(testCase.registersPair), "reads both registers as one value")
+	}
+
+	t.Run("when stack pointer", func(t *testing.T) {
+		ee.setPair(sp, 0xabcd)
+		assert.Equal(t, uint16(0xabcd), ee.sp, "sets stack pointer")
+		assert.Equal(t, uint16(0xabcd), ee.pair(sp), "reads stack pointer")
+	})
+
+	t.Run("when psw", func(t *testing.T) {
+		ee.setPair(psw, 0x1fd5)
+		assert.Equal(t, uint8(0x1f), ee.a, "stores high byte in accumulator")
+		assert.Equal(t, condCodes{s: 1, z: 1, ac: 1, p: 1, cy: 1}, *ee.cc, "stores low byte in condition codes")
+	})
+}
+
+func TestINX(t *testing.T) {
+	t.Run("when low byte overflows", func(t *testing.T) {
+		ee := New()
+		ee.h, ee.l = 0x38, 0xff
+
+		ee.inx(hl)
+		assert.Equal(t, uint8(0x39), ee.h, "carries to high byte")
+		assert.Equal(t, uint8(0x00), ee.l, "wraps low byte")
+	})
+
+	t.Run("when whole pair overflows", func(t *testing.T) {
+		ee := New()
+		ee.sp = 0xffff
+
+		ee.inx(sp)
+		assert.Equal(t, uint16(0x0000), ee.sp, "wraps to zero")
+	})
+}
+
+func TestDCX(t *testing.T) {
+	t.Run("when low byte underflows", func(t *testing.T) {
+		ee := New()
+		ee.d, ee.e = 0x98, 0x00
+
+		ee.dcx(de)
+		assert.Equal(t, uint8(0x97), ee.d, "borrows from high byte")
+		assert.Equal(t, uint8(0xff), ee.e, "wraps low byte")
+	})
+
+	t.Run("when whole pair underflows", func(t *testing.T) {
+		ee := New()
+		ee.b, ee.c = 0x00, 0x00
+
+		ee.dcx(bc)
+		assert.Equal(t, uint16(0xffff), ee.pair(bc), "wraps to 0xffff")
+	})
+}
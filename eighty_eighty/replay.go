@@ -0,0 +1,248 @@
+package eighty_eighty
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+)
+
+// recordingMagic starts every recording file; recordingVersion is bumped whenever layout changes
+const (
+	recordingMagic   = "8080RPLY"
+	recordingVersion = 1
+)
+
+// EventKind tells what kind of input reached CPU from the outside
+type EventKind uint8
+
+const (
+	// EventIn is Value returned to IN instruction reading Port; key presses and other device
+	// inputs reach CPU this way
+	EventIn EventKind = iota
+	// EventInterrupt is interrupt request with Data placed on the data bus
+	EventInterrupt
+	// EventClearInterrupt drops pending interrupt request
+	EventClearInterrupt
+	// EventInterruptLine drives 8085 interrupt input Port to level Value
+	EventInterruptLine
+	// EventSID drives 8085 SID pin to level Value
+	EventSID
+)
+
+// Event is a single input CPU got from the outside at provided cycle
+type Event struct {
+	Cycles uint64
+	Kind   EventKind
+	Port   uint8
+	Value  uint8
+	Data   []uint8
+}
+
+// Recording holds inputs CPU got while recording along with snapshot of the state it started in;
+// replaying it on the same machine reproduces the execution exactly
+type Recording struct {
+	Snapshot []byte // state recording started in, empty when memory bus doesn't support snapshots
+	End      uint64 // cycle recording was stopped at
+	Events   []Event
+}
+
+type recordingHeader struct {
+	Magic   [len(recordingMagic)]byte
+	Version uint16
+}
+
+type eventHeader struct {
+	Cycles uint64
+	Kind   EventKind
+	Port   uint8
+	Value  uint8
+}
+
+// StartRecording starts recording inputs CPU gets; the recording begins with a snapshot of the
+// current state when memory bus supports it, otherwise it has to be replayed from the same state
+func (s *CPU) StartRecording() error {
+	rc := &Recording{}
+	if _, ok := s.mem.(Snapshotter); ok {
+		var buf bytes.Buffer
+		if err := s.SaveSnapshot(&buf); err != nil {
+			return err
+		}
+		rc.Snapshot = buf.Bytes()
+	}
+
+	s.recording = rc
+	return nil
+}
+
+// StopRecording stops recording and returns recorded inputs, nil when CPU wasn't recording
+func (s *CPU) StopRecording() *Recording {
+	rc := s.recording
+	if rc != nil {
+		rc.End = s.cycles
+	}
+
+	s.recording = nil
+	return rc
+}
+
+// Replay restores state provided recording started in and feeds CPU with recorded inputs until
+// it reaches the cycle recording was stopped at. Meanwhile inputs from the outside are ignored,
+// port handlers are still called but values they return are replaced with recorded ones.
+func (s *CPU) Replay(rc *Recording) error {
+	if len(rc.Snapshot) > 0 {
+		if err := s.LoadSnapshot(bytes.NewReader(rc.Snapshot)); err != nil {
+			return err
+		}
+	}
+
+	s.replay = rc
+	s.replayed = 0
+	return nil
+}
+
+// Replaying reports whether CPU is still replaying a recording
+func (s *CPU) Replaying() bool {
+	return s.replaying()
+}
+
+// replaying reports whether inputs come from a recording; replay finishes once all recorded events
+// were applied and the end of the recording was reached
+func (s *CPU) replaying() bool {
+	if s.replay != nil && s.replayed == len(s.replay.Events) && s.cycles >= s.replay.End {
+		s.replay = nil
+	}
+	return s.replay != nil
+}
+
+// record adds event to the recording, if there's one going on
+func (s *CPU) record(event Event) {
+	if s.recording != nil {
+		event.Cycles = s.cycles
+		s.recording.Events = append(s.recording.Events, event)
+	}
+}
+
+// replayEvents applies recorded events due at the current cycle
+func (s *CPU) replayEvents() error {
+	events := s.replay.Events
+	for ; s.replayed < len(events) && events[s.replayed].Cycles <= s.cycles; s.replayed++ {
+		event := events[s.replayed]
+		switch event.Kind {
+		case EventIn:
+			if event.Cycles == s.cycles {
+				return nil // read by the instruction about to be executed
+			}
+			return s.diverged("IN %#02x wasn't executed", event.Port)
+		case EventInterrupt:
+			s.intRequest = event.Data
+		case EventClearInterrupt:
+			s.intRequest = nil
+		case EventInterruptLine:
+			s.setInterruptLine(InterruptLine(event.Port), event.Value == 1)
+		case EventSID:
+			s.i85.sid = event.Value == 1
+		}
+	}
+
+	return nil
+}
+
+// replayIn returns value recorded for IN instruction reading provided port at the current cycle
+func (s *CPU) replayIn(port uint8) (uint8, error) {
+	events := s.replay.Events
+	if s.replayed == len(events) {
+		return openBus, s.diverged("IN %#02x wasn't recorded", port)
+	}
+
+	event := events[s.replayed]
+	if event.Kind != EventIn || event.Port != port || event.Cycles != s.cycles {
+		return openBus, s.diverged("IN %#02x wasn't recorded", port)
+	}
+
+	s.replayed++
+	return event.Value, nil
+}
+
+func (s *CPU) diverged(format string, args ...interface{}) error {
+	s.replay = nil
+	return fmt.Errorf("replay diverged at cycle %d: %s", s.cycles, fmt.Sprintf(format, args...))
+}
+
+// Save writes recording in a versioned binary format
+func (rc *Recording) Save(w io.Writer) error {
+	header := recordingHeader{Version: recordingVersion}
+	copy(header.Magic[:], recordingMagic)
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if err := writeBytes(w, rc.Snapshot); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, rc.End); err != nil {
+		return err
+	}
+
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(rc.Events))); err != nil {
+		return err
+	}
+	for _, event := range rc.Events {
+		header := eventHeader{Cycles: event.Cycles, Kind: event.Kind, Port: event.Port, Value: event.Value}
+		if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+			return err
+		}
+		if err := writeBytes(w, event.Data); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// LoadRecording reads recording written with Save
+func LoadRecording(r io.Reader) (*Recording, error) {
+	var header recordingHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return nil, fmt.Errorf("cant read recording header: %s", err.Error())
+	}
+	switch {
+	case string(header.Magic[:]) != recordingMagic:
+		return nil, fmt.Errorf("not a recording")
+	case header.Version != recordingVersion:
+		return nil, fmt.Errorf("unsupported recording version %d", header.Version)
+	}
+
+	rc := &Recording{}
+	var err error
+	if rc.Snapshot, err = readBytes(r); err != nil {
+		return nil, err
+	}
+	if err := binary.Read(r, binary.LittleEndian, &rc.End); err != nil {
+		return nil, err
+	}
+
+	var count uint32
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return nil, err
+	}
+	for i := uint32(0); i < count; i++ {
+		var header eventHeader
+		if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+			return nil, err
+		}
+		data, err := readBytes(r)
+		if err != nil {
+			return nil, err
+		}
+
+		rc.Events = append(rc.Events, Event{
+			Cycles: header.Cycles,
+			Kind:   header.Kind,
+			Port:   header.Port,
+			Value:  header.Value,
+			Data:   data,
+		})
+	}
+
+	return rc, nil
+}
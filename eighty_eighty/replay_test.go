@@ -0,0 +1,111 @@
+package eighty_eighty
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// summing program: adds values read from port 0 at 0x2000 and counts RST 1 interrupts at 0x2001;
+// segments are listed in order of addresses and must not overlap
+var summingProgram = []struct {
+	address uint16
+	code    []byte
+}{
+	{0x0000, []byte{0xc3, 0x40, 0x00}}, // JMP 0x0040
+	{0x0008, []byte{
+		0x3a, 0x01, 0x20, // LDA 0x2001
+		0x3c,             // INR A
+		0x32, 0x01, 0x20, // STA 0x2001
+		0xfb, // EI
+		0xc9, // RET
+	}},
+	{0x0040, []byte{
+		0x31, 0x00, 0x24, // LXI SP,0x2400
+		0xfb, // EI
+	}},
+	{0x0044, []byte{
+		0xdb, 0x00, // IN 0
+		0x47,             // MOV B,A
+		0x3a, 0x00, 0x20, // LDA 0x2000
+		0x80,             // ADD B
+		0x32, 0x00, 0x20, // STA 0x2000
+		0xc3, 0x44, 0x00, // JMP 0x0044
+	}},
+}
+
+func newSumming(t *testing.T, input func(port uint8) uint8) *CPU {
+	ee := New(WithPorts(0, 0, PortFuncs{InFunc: input}))
+	var end int
+	for _, segment := range summingProgram {
+		if int(segment.address) < end {
+			t.Fatalf("segment at %#04x overlaps the previous one", segment.address)
+		}
+		ee.Load(segment.address, segment.code)
+		end = int(segment.address) + len(segment.code)
+	}
+
+	return ee
+}
+
+func TestRecording(t *testing.T) {
+	var counter uint8
+	ee := newSumming(t, func(port uint8) uint8 {
+		counter += 7
+		return counter
+	})
+	for i := 0; i < 100; i++ {
+		ee.Step()
+	}
+
+	assert.Nil(t, ee.StartRecording())
+	for i := 0; i < 5000; i++ {
+		if i%97 == 0 {
+			ee.Interrupt(RST(1))
+		}
+		_, err := ee.Step()
+		assert.Nil(t, err)
+	}
+	recording := ee.StopRecording()
+	assert.NotZero(t, ee.ReadMemory(0x2001), "serves interrupts")
+
+	var saved bytes.Buffer
+	assert.Nil(t, recording.Save(&saved))
+	loaded, err := LoadRecording(&saved)
+	assert.Nil(t, err)
+	assert.Equal(t, recording, loaded, "saves and loads recording")
+
+	t.Run("replaying", func(t *testing.T) {
+		replayed := newSumming(t, func(port uint8) uint8 { return 0 })
+		assert.Nil(t, replayed.Replay(loaded))
+
+		for replayed.Replaying() {
+			replayed.Interrupt(RST(2)) // ignored
+			_, err := replayed.Step()
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, ee.state(), replayed.state(), "reproduces CPU state")
+		assert.Equal(t, ee.mem, replayed.mem, "reproduces memory")
+	})
+
+	t.Run("when execution diverges", func(t *testing.T) {
+		replayed := newSumming(t, nil)
+		assert.Nil(t, replayed.Replay(loaded))
+		replayed.WriteMemory(0x0044, 0x00) // NOP instead of IN 0
+
+		var err error
+		for replayed.Replaying() && err == nil {
+			_, err = replayed.Step()
+		}
+		if assert.Error(t, err) {
+			assert.Contains(t, err.Error(), "replay diverged")
+		}
+		assert.False(t, replayed.Replaying(), "stops replaying")
+	})
+
+	t.Run("when file isn't a recording", func(t *testing.T) {
+		_, err := LoadRecording(bytes.NewReader([]byte("8080SNAP and some more bytes")))
+		assert.EqualError(t, err, "not a recording")
+	})
+}
@@ -0,0 +1,198 @@
+package eighty_eighty
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+)
+
+// keyframe is a snapshot taken before executing step with provided number
+type keyframe struct {
+	step  uint64
+	frame bool // taken at the start of a frame
+	data  []byte
+}
+
+// memoryWrite remembers value overwritten by an instruction
+type memoryWrite struct {
+	address uint16
+	old     uint8
+}
+
+// delta holds everything needed to undo a single step
+type delta struct {
+	state      cpuState
+	intRequest []uint8
+	writes     []memoryWrite
+}
+
+// Rewinder runs CPU while recording its history so it can be stepped backwards. It keeps a ring of
+// keyframes, snapshots taken every interval steps and at the start of every frame, and deltas of
+// registers and memory for every step since the oldest one. Stepping back undoes deltas, which
+// leaves port handlers alone; rewinding frames restores keyframes, peripherals included.
+type Rewinder struct {
+	cpu       *CPU
+	mem       Bus // bus CPU was attached to before rewinder started recording writes
+	interval  uint64
+	capacity  int
+	keyframes []keyframe
+	deltas    []delta // deltas[i] undoes step number first+i
+	first     uint64
+	steps     uint64
+	current   *delta
+}
+
+// rewindBus records writes of the current step before passing them to the real bus
+type rewindBus struct {
+	rw *Rewinder
+}
+
+func (rb *rewindBus) Read(address uint16) uint8 {
+	return rb.rw.mem.Read(address)
+}
+
+func (rb *rewindBus) Write(address uint16, val uint8) {
+	if d := rb.rw.current; d != nil {
+		d.writes = append(d.writes, memoryWrite{address: address, old: rb.rw.mem.Read(address)})
+	}
+	rb.rw.mem.Write(address, val)
+}
+
+func (rb *rewindBus) Snapshot(w io.Writer) error {
+	return rb.rw.mem.(Snapshotter).Snapshot(w)
+}
+
+func (rb *rewindBus) Restore(r io.Reader) error {
+	return rb.rw.mem.(Snapshotter).Restore(r)
+}
+
+// NewRewinder starts recording history of provided CPU, taking a keyframe every interval steps and
+// keeping up to capacity of them; memory bus of the CPU must support snapshots. From now on CPU
+// should be stepped only through the rewinder.
+func NewRewinder(cpu *CPU, interval, capacity int) (*Rewinder, error) {
+	if interval < 1 || capacity < 1 {
+		return nil, fmt.Errorf("interval and capacity must be positive")
+	}
+	if _, ok := cpu.mem.(Snapshotter); !ok {
+		return nil, fmt.Errorf("memory bus %T doesn't support snapshots", cpu.mem)
+	}
+
+	rw := &Rewinder{
+		cpu:      cpu,
+		mem:      cpu.mem,
+		interval: uint64(interval),
+		capacity: capacity,
+	}
+	cpu.mem = &rewindBus{rw: rw}
+
+	if err := rw.keyframe(false); err != nil {
+		cpu.mem = rw.mem
+		return nil, err
+	}
+	return rw, nil
+}
+
+// Step executes a single CPU step recording how to undo it
+func (rw *Rewinder) Step() (int, error) {
+	last := rw.keyframes[len(rw.keyframes)-1]
+	if rw.steps-last.step >= rw.interval {
+		if err := rw.keyframe(false); err != nil {
+			return 0, err
+		}
+	}
+
+	rw.deltas = append(rw.deltas, delta{state: rw.cpu.state(), intRequest: rw.cpu.intRequest})
+	rw.current = &rw.deltas[len(rw.deltas)-1]
+	spent, err := rw.cpu.Step()
+	rw.current = nil
+	rw.steps++
+
+	return spent, err
+}
+
+// Cycles returns number of cycles CPU executed so far
+func (rw *Rewinder) Cycles() uint64 { return rw.cpu.Cycles() }
+
+// Idle reports whether CPU is halted with no interrupt to wake it up
+func (rw *Rewinder) Idle() bool { return rw.cpu.Idle() }
+
+// Frame marks the start of a frame, which RewindFrame can go back to
+func (rw *Rewinder) Frame() error {
+	last := &rw.keyframes[len(rw.keyframes)-1]
+	if last.step == rw.steps {
+		last.frame = true
+		return nil
+	}
+
+	return rw.keyframe(true)
+}
+
+// History returns number of steps which can be undone
+func (rw *Rewinder) History() int {
+	return len(rw.deltas)
+}
+
+// StepBack undoes the last step
+func (rw *Rewinder) StepBack() error {
+	if len(rw.deltas) == 0 {
+		return fmt.Errorf("no history to step back")
+	}
+
+	d := rw.deltas[len(rw.deltas)-1]
+	for i := len(d.writes) - 1; i >= 0; i-- {
+		rw.mem.Write(d.writes[i].address, d.writes[i].old)
+	}
+	rw.cpu.setState(d.state)
+	rw.cpu.intRequest = d.intRequest
+
+	rw.deltas = rw.deltas[:len(rw.deltas)-1]
+	rw.steps--
+	rw.dropFuture()
+	return nil
+}
+
+// RewindFrame goes back to the start of the current frame, or to the start of the previous one
+// when no step was made in the current frame yet
+func (rw *Rewinder) RewindFrame() error {
+	for i := len(rw.keyframes) - 1; i >= 0; i-- {
+		kf := rw.keyframes[i]
+		if !kf.frame || kf.step >= rw.steps {
+			continue
+		}
+
+		if err := rw.cpu.LoadSnapshot(bytes.NewReader(kf.data)); err != nil {
+			return err
+		}
+		rw.deltas = rw.deltas[:kf.step-rw.first]
+		rw.steps = kf.step
+		rw.dropFuture()
+		return nil
+	}
+
+	return fmt.Errorf("no frame to rewind to")
+}
+
+// keyframe takes a snapshot of the current state and forgets history older than the oldest keyframe
+func (rw *Rewinder) keyframe(frame bool) error {
+	var buf bytes.Buffer
+	if err := rw.cpu.SaveSnapshot(&buf); err != nil {
+		return err
+	}
+	rw.keyframes = append(rw.keyframes, keyframe{step: rw.steps, frame: frame, data: buf.Bytes()})
+
+	if len(rw.keyframes) > rw.capacity {
+		rw.keyframes = append(rw.keyframes[:0], rw.keyframes[1:]...)
+		oldest := rw.keyframes[0].step
+		rw.deltas = append(rw.deltas[:0], rw.deltas[oldest-rw.first:]...)
+		rw.first = oldest
+	}
+
+	return nil
+}
+
+// dropFuture forgets keyframes taken after the current step, but always keeps the oldest one
+func (rw *Rewinder) dropFuture() {
+	for len(rw.keyframes) > 1 && rw.keyframes[len(rw.keyframes)-1].step > rw.steps {
+		rw.keyframes = rw.keyframes[:len(rw.keyframes)-1]
+	}
+}
@@ -0,0 +1,93 @@
+package eighty_eighty
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRewinder(t *testing.T) {
+	t.Run("stepping back", func(t *testing.T) {
+		ee, _ := newSnapshotted()
+		rw, err := NewRewinder(ee, 1000, 20)
+		assert.Nil(t, err)
+
+		for i := 0; i < 500; i++ {
+			rw.Step()
+		}
+		rw.Step()
+		next := ee.state()
+		rw.StepBack()
+		state := ee.state()
+		counter := ee.ReadMemory(0x2000)
+
+		for i := 0; i < 10000; i++ {
+			_, err := rw.Step()
+			assert.Nil(t, err)
+		}
+		assert.Equal(t, 10500, rw.History())
+
+		for i := 0; i < 10000; i++ {
+			assert.Nil(t, rw.StepBack())
+		}
+		assert.Equal(t, state, ee.state(), "restores registers")
+		assert.Equal(t, counter, ee.ReadMemory(0x2000), "restores memory")
+
+		rw.Step()
+		assert.Equal(t, next, ee.state(), "continues from restored state")
+	})
+
+	t.Run("when history runs out", func(t *testing.T) {
+		ee, _ := newSnapshotted()
+		rw, _ := NewRewinder(ee, 100, 3)
+
+		for i := 0; i < 1000; i++ {
+			rw.Step()
+		}
+		assert.Equal(t, 300, rw.History(), "keeps steps since the oldest keyframe")
+
+		for rw.History() > 0 {
+			rw.StepBack()
+		}
+		assert.NotNil(t, rw.StepBack(), "fails")
+	})
+
+	t.Run("rewinding frames", func(t *testing.T) {
+		ee, lt := newSnapshotted()
+		rw, _ := NewRewinder(ee, 1000, 20)
+
+		for frame := 0; frame < 3; frame++ {
+			assert.Nil(t, rw.Frame())
+			for i := 0; i < 100; i++ {
+				rw.Step()
+			}
+		}
+		latched, cycles := lt.val, ee.Cycles()
+
+		assert.Nil(t, rw.RewindFrame(), "goes back to the start of current frame")
+		assert.Equal(t, 200, rw.History())
+		assert.NotEqual(t, latched, lt.val, "restores peripherals")
+
+		assert.Nil(t, rw.RewindFrame(), "goes back to the previous frame")
+		assert.Equal(t, 100, rw.History())
+
+		for i := 0; i < 200; i++ {
+			rw.Step()
+		}
+		assert.Equal(t, latched, lt.val, "replays the same way")
+		assert.Equal(t, cycles, ee.Cycles())
+
+		rw.RewindFrame()
+		rw.RewindFrame()
+		rw.RewindFrame()
+		assert.NotNil(t, rw.RewindFrame(), "fails with no frame left")
+	})
+
+	t.Run("when memory bus doesn't support snapshots", func(t *testing.T) {
+		ee := New(WithBus(Callback{}))
+
+		_, err := NewRewinder(ee, 100, 3)
+		assert.NotNil(t, err)
+		assert.Equal(t, Callback{}, ee.Bus(), "leaves CPU alone")
+	})
+}
@@ -0,0 +1,203 @@
+package eighty_eighty
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	// singleStepDir holds hand-checked cases
+	singleStepDir = "testdata/singlestep"
+	// communityDir holds community single step tests fetched with scripts/fetch-testdata.sh
+	communityDir = "testdata/sst8080"
+)
+
+// singleStepState is a CPU state in single step tests format; ram holds [address, value] pairs
+type singleStepState struct {
+	PC  uint16      `json:"pc"`
+	SP  uint16      `json:"sp"`
+	A   uint8       `json:"a"`
+	B   uint8       `json:"b"`
+	C   uint8       `json:"c"`
+	D   uint8       `json:"d"`
+	E   uint8       `json:"e"`
+	F   uint8       `json:"f"`
+	H   uint8       `json:"h"`
+	L   uint8       `json:"l"`
+	RAM [][2]uint16 `json:"ram"`
+}
+
+// singleStepCycles is either a plain cycle count or a list with an entry per bus cycle
+type singleStepCycles int
+
+func (sc *singleStepCycles) UnmarshalJSON(data []byte) error {
+	var count int
+	if err := json.Unmarshal(data, &count); err == nil {
+		*sc = singleStepCycles(count)
+		return nil
+	}
+
+	var perCycle []json.RawMessage
+	if err := json.Unmarshal(data, &perCycle); err != nil {
+		return fmt.Errorf("cycles are neither a count nor a list: %s", err.Error())
+	}
+
+	*sc = singleStepCycles(len(perCycle))
+	return nil
+}
+
+// singleStepPort is a port access made by the instruction: [port, value, "r" or "w"]
+type singleStepPort struct {
+	Port  uint8
+	Value uint8
+	Read  bool
+}
+
+func (sp *singleStepPort) UnmarshalJSON(data []byte) error {
+	var fields []json.RawMessage
+	if err := json.Unmarshal(data, &fields); err != nil || len(fields) != 3 {
+		return fmt.Errorf("port access isn't a [port, value, direction] list")
+	}
+
+	var port uint16
+	var direction string
+	if err := json.Unmarshal(fields[0], &port); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[1], &sp.Value); err != nil {
+		return err
+	}
+	if err := json.Unmarshal(fields[2], &direction); err != nil {
+		return err
+	}
+
+	sp.Port = uint8(port) // 8080 puts port number on both halves of the address bus
+	sp.Read = direction == "r"
+	return nil
+}
+
+type singleStepCase struct {
+	Name    string           `json:"name"`
+	Initial singleStepState  `json:"initial"`
+	Final   singleStepState  `json:"final"`
+	Cycles  singleStepCycles `json:"cycles"`
+	Ports   []singleStepPort `json:"ports"`
+}
+
+// newCPU returns CPU which ports return values read by the case
+func (ssc singleStepCase) newCPU() *CPU {
+	inputs := make(map[uint8]uint8)
+	for _, access := range ssc.Ports {
+		if access.Read {
+			inputs[access.Port] = access.Value
+		}
+	}
+
+	ee := New(WithPorts(0x00, 0xff, PortFuncs{InFunc: func(port uint8) uint8 {
+		if val, ok := inputs[port]; ok {
+			return val
+		}
+		return openBus
+	}}))
+	ssc.Initial.apply(ee)
+	return ee
+}
+
+func (sss singleStepState) apply(ee *CPU) {
+	ee.pc, ee.sp = sss.PC, sss.SP
+	ee.a, ee.b, ee.c, ee.d, ee.e, ee.h, ee.l = sss.A, sss.B, sss.C, sss.D, sss.E, sss.H, sss.L
+	ee.cc.unpack(sss.F)
+
+	for _, cell := range sss.RAM {
+		ee.mem.Write(cell[0], uint8(cell[1]))
+	}
+}
+
+// mismatches lists every field of provided CPU which differs from expected state
+func (sss singleStepState) mismatches(ee *CPU) []string {
+	var diffs []string
+	compare := func(field string, expected, actual uint16) {
+		if expected != actual {
+			diffs = append(diffs, fmt.Sprintf("%s: expected %#x, got %#x", field, expected, actual))
+		}
+	}
+
+	compare("pc", sss.PC, ee.pc)
+	compare("sp", sss.SP, ee.sp)
+	compare("a", uint16(sss.A), uint16(ee.a))
+	compare("b", uint16(sss.B), uint16(ee.b))
+	compare("c", uint16(sss.C), uint16(ee.c))
+	compare("d", uint16(sss.D), uint16(ee.d))
+	compare("e", uint16(sss.E), uint16(ee.e))
+	compare("h", uint16(sss.H), uint16(ee.h))
+	compare("l", uint16(sss.L), uint16(ee.l))
+	compare("f", uint16(sss.F), uint16(ee.cc.pack()))
+
+	for _, cell := range sss.RAM {
+		compare(fmt.Sprintf("ram[%#04x]", cell[0]), cell[1], uint16(ee.mem.Read(cell[0])))
+	}
+
+	return diffs
+}
+
+// TestSingleStep runs hand-checked cases from testdata/singlestep and community single step tests
+// from testdata/sst8080, which aren't distributed with the repository; see testdata/README.md for
+// fetching them. Each file holds cases of a single opcode and is named after it, e.g. 3c.json.
+func TestSingleStep(t *testing.T) {
+	t.Run("hand-checked", func(t *testing.T) {
+		runSingleStep(t, singleStepDir)
+	})
+
+	t.Run("community", func(t *testing.T) {
+		runSingleStep(t, communityDir)
+	})
+}
+
+// runSingleStep runs fixtures found in provided directory; a partial set runs the opcodes it has
+func runSingleStep(t *testing.T, dir string) {
+	files, err := filepath.Glob(filepath.Join(dir, "*.json"))
+	if err != nil {
+		t.Fatalf("cant list fixtures: %s", err.Error())
+	}
+	if len(files) == 0 {
+		t.Skipf("no fixtures found in %s", dir)
+	}
+
+	for _, file := range files {
+		opCode := strings.TrimSuffix(filepath.Base(file), ".json")
+
+		t.Run(opCode, func(t *testing.T) {
+			data, err := ioutil.ReadFile(file)
+			if err != nil {
+				t.Fatalf("cant read fixture: %s", err.Error())
+			}
+
+			var cases []singleStepCase
+			if err := json.Unmarshal(data, &cases); err != nil {
+				t.Fatalf("cant parse fixture: %s", err.Error())
+			}
+
+			for _, testCase := range cases {
+				ee := testCase.newCPU()
+
+				spent, err := ee.Step()
+				if err != nil {
+					t.Errorf("opcode %s, case %q: %s", opCode, testCase.Name, err.Error())
+					continue
+				}
+
+				diffs := testCase.Final.mismatches(ee)
+				if spent != int(testCase.Cycles) {
+					diffs = append(diffs, fmt.Sprintf("cycles: expected %d, got %d", testCase.Cycles, spent))
+				}
+				for _, diff := range diffs {
+					t.Errorf("opcode %s, case %q: %s", opCode, testCase.Name, diff)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,264 @@
+package eighty_eighty
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"reflect"
+)
+
+// snapshotMagic starts every snapshot; snapshotVersion is bumped whenever layout changes
+const (
+	snapshotMagic   = "8080SNAP"
+	snapshotVersion = 1
+
+	maxSectionSize = 1 << 24 // guards against allocating absurd amounts for corrupted files
+)
+
+// Snapshotter is a memory or peripheral which state is saved along with CPU. Memory bus and port
+// handlers implementing it are included in snapshots automatically.
+type Snapshotter interface {
+	Snapshot(w io.Writer) error
+	Restore(r io.Reader) error
+}
+
+// snapshotHeader identifies the file and the processor it was taken from
+type snapshotHeader struct {
+	Magic   [len(snapshotMagic)]byte
+	Version uint16
+	Variant uint8
+}
+
+// cpuState is the fixed size part of CPU state as laid out in snapshots
+type cpuState struct {
+	A, B, C, D, E, H, L uint8
+	Flags               uint8
+	SP, PC              uint16
+	IntEnable           uint8
+	IntDelay            bool
+	Halted              bool
+	Cycles              uint64
+
+	Lines   [4]bool
+	Trap    bool
+	RST75   bool
+	Masks   uint8
+	TrapIE  uint8
+	Trapped bool
+	SID     bool
+	SOD     bool
+}
+
+// SaveSnapshot writes complete machine state to provided writer: registers, flags, interrupt state,
+// cycle counter, memory and state of port handlers implementing Snapshotter. The memory bus must
+// implement Snapshotter, which RAM and Map do.
+func (s *CPU) SaveSnapshot(w io.Writer) error {
+	mem, ok := s.mem.(Snapshotter)
+	if !ok {
+		return fmt.Errorf("memory bus %T doesn't support snapshots", s.mem)
+	}
+
+	header := snapshotHeader{Version: snapshotVersion, Variant: uint8(s.variant)}
+	copy(header.Magic[:], snapshotMagic)
+	if err := binary.Write(w, binary.LittleEndian, header); err != nil {
+		return err
+	}
+	if err := binary.Write(w, binary.LittleEndian, s.state()); err != nil {
+		return err
+	}
+	if err := writeBytes(w, s.intRequest); err != nil {
+		return err
+	}
+
+	if err := writeSection(w, mem); err != nil {
+		return fmt.Errorf("cant save memory: %s", err.Error())
+	}
+
+	peripherals := s.peripherals()
+	if err := binary.Write(w, binary.LittleEndian, uint16(len(peripherals))); err != nil {
+		return err
+	}
+	for _, peripheral := range peripherals {
+		if err := writeSection(w, peripheral); err != nil {
+			return fmt.Errorf("cant save %T: %s", peripheral, err.Error())
+		}
+	}
+
+	return nil
+}
+
+// LoadSnapshot restores machine state saved with SaveSnapshot; CPU has to be set up the same way
+// as the one snapshot was taken from, with the same variant, memory layout and peripherals. Memory
+// and peripherals may be left partially restored when it fails.
+func (s *CPU) LoadSnapshot(r io.Reader) error {
+	mem, ok := s.mem.(Snapshotter)
+	if !ok {
+		return fmt.Errorf("memory bus %T doesn't support snapshots", s.mem)
+	}
+
+	var header snapshotHeader
+	if err := binary.Read(r, binary.LittleEndian, &header); err != nil {
+		return fmt.Errorf("cant read snapshot header: %s", err.Error())
+	}
+	switch {
+	case string(header.Magic[:]) != snapshotMagic:
+		return fmt.Errorf("not a snapshot")
+	case header.Version != snapshotVersion:
+		return fmt.Errorf("unsupported snapshot version %d", header.Version)
+	case Variant(header.Variant) != s.variant:
+		return fmt.Errorf("snapshot of variant %d can't be loaded to variant %d", header.Variant, s.variant)
+	}
+
+	var state cpuState
+	if err := binary.Read(r, binary.LittleEndian, &state); err != nil {
+		return fmt.Errorf("cant read CPU state: %s", err.Error())
+	}
+	intRequest, err := readBytes(r)
+	if err != nil {
+		return fmt.Errorf("cant read CPU state: %s", err.Error())
+	}
+
+	if err := readSection(r, mem); err != nil {
+		return fmt.Errorf("cant restore memory: %s", err.Error())
+	}
+
+	var count uint16
+	if err := binary.Read(r, binary.LittleEndian, &count); err != nil {
+		return err
+	}
+	peripherals := s.peripherals()
+	if int(count) != len(peripherals) {
+		return fmt.Errorf("snapshot has %d peripherals, CPU has %d", count, len(peripherals))
+	}
+	for _, peripheral := range peripherals {
+		if err := readSection(r, peripheral); err != nil {
+			return fmt.Errorf("cant restore %T: %s", peripheral, err.Error())
+		}
+	}
+
+	s.setState(state)
+	s.intRequest = intRequest
+	return nil
+}
+
+func (s *CPU) state() cpuState {
+	return cpuState{
+		A: s.a, B: s.b, C: s.c, D: s.d, E: s.e, H: s.h, L: s.l,
+		Flags:     s.cc.pack(),
+		SP:        s.sp,
+		PC:        s.pc,
+		IntEnable: s.int_enable,
+		IntDelay:  s.intDelay,
+		Halted:    s.halted,
+		Cycles:    s.cycles,
+		Lines:     s.i85.lines,
+		Trap:      s.i85.trap,
+		RST75:     s.i85.rst75,
+		Masks:     s.i85.masks,
+		TrapIE:    s.i85.trapIE,
+		Trapped:   s.i85.trapped,
+		SID:       s.i85.sid,
+		SOD:       s.i85.sod,
+	}
+}
+
+func (s *CPU) setState(state cpuState) {
+	s.a, s.b, s.c, s.d, s.e, s.h, s.l = state.A, state.B, state.C, state.D, state.E, state.H, state.L
+	s.cc.unpack(state.Flags)
+	s.sp = state.SP
+	s.pc = state.PC
+	s.int_enable = state.IntEnable
+	s.intDelay = state.IntDelay
+	s.halted = state.Halted
+	s.cycles = state.Cycles
+	s.i85.lines = state.Lines
+	s.i85.trap = state.Trap
+	s.i85.rst75 = state.RST75
+	s.i85.masks = state.Masks
+	s.i85.trapIE = state.TrapIE
+	s.i85.trapped = state.Trapped
+	s.i85.sid = state.SID
+	s.i85.sod = state.SOD
+}
+
+// peripherals returns port handlers implementing Snapshotter in order of ports they are attached
+// to, each one once; handlers which can't be compared are listed for every port
+func (s *CPU) peripherals() []Snapshotter {
+	var peripherals []Snapshotter
+	seen := make(map[IOHandler]bool)
+
+	for _, handler := range s.ports {
+		peripheral, ok := handler.(Snapshotter)
+		if !ok {
+			continue
+		}
+
+		if reflect.TypeOf(handler).Comparable() {
+			if seen[handler] {
+				continue
+			}
+			seen[handler] = true
+		}
+		peripherals = append(peripherals, peripheral)
+	}
+
+	return peripherals
+}
+
+// writeSection writes state of provided snapshotter preceded with its length, so reading it back
+// can tell when the snapshotter consumed less or more than it should
+func writeSection(w io.Writer, sn Snapshotter) error {
+	var buf bytes.Buffer
+	if err := sn.Snapshot(&buf); err != nil {
+		return err
+	}
+
+	return writeBytes(w, buf.Bytes())
+}
+
+func readSection(r io.Reader, sn Snapshotter) error {
+	data, err := readBytes(r)
+	if err != nil {
+		return err
+	}
+
+	reader := bytes.NewReader(data)
+	if err := sn.Restore(reader); err != nil {
+		return err
+	}
+	if reader.Len() > 0 {
+		return fmt.Errorf("%d bytes of state left unread", reader.Len())
+	}
+
+	return nil
+}
+
+func writeBytes(w io.Writer, data []byte) error {
+	if err := binary.Write(w, binary.LittleEndian, uint32(len(data))); err != nil {
+		return err
+	}
+
+	_, err := w.Write(data)
+	return err
+}
+
+// readBytes reads data written with writeBytes; empty data is returned as nil
+func readBytes(r io.Reader) ([]byte, error) {
+	var size uint32
+	if err := binary.Read(r, binary.LittleEndian, &size); err != nil {
+		return nil, err
+	}
+	if size == 0 {
+		return nil, nil
+	}
+	if size > maxSectionSize {
+		return nil, fmt.Errorf("section of %d bytes is too big", size)
+	}
+
+	data := make([]byte, size)
+	if _, err := io.ReadFull(r, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
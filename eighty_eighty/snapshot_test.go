@@ -0,0 +1,127 @@
+package eighty_eighty
+
+import (
+	"bytes"
+	"io"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// latch is a peripheral remembering last value written to it
+type latch struct {
+	val uint8
+}
+
+func (lt *latch) In(port uint8) uint8        { return lt.val }
+func (lt *latch) Out(port uint8, val uint8)  { lt.val = val }
+func (lt *latch) Snapshot(w io.Writer) error { _, err := w.Write([]byte{lt.val}); return err }
+func (lt *latch) Restore(r io.Reader) error {
+	buf := make([]byte, 1)
+	_, err := io.ReadFull(r, buf)
+	lt.val = buf[0]
+	return err
+}
+
+// counting program: increments memory at 0x2000 and sends it to port 1 in a loop
+var countingProgram = []byte{
+	0x21, 0x00, 0x20, // LXI H,0x2000
+	0x34,       // INR M
+	0x7e,       // MOV A,M
+	0xd3, 0x01, // OUT 1
+	0xc3, 0x03, 0x00, // JMP 0x0003
+}
+
+func newSnapshotted(opts ...Option) (*CPU, *latch) {
+	lt := &latch{}
+	opts = append(opts, WithPorts(1, 2, lt))
+	ee := New(opts...)
+	ee.Load(0, countingProgram)
+	ee.sp = 0x2400
+
+	return ee, lt
+}
+
+func TestSnapshot(t *testing.T) {
+	t.Run("restoring saved state", func(t *testing.T) {
+		ee, lt := newSnapshotted(WithVariant(Intel8085))
+		for i := 0; i < 50; i++ {
+			ee.Step()
+		}
+		ee.int_enable = 1
+		ee.Interrupt(RST(1))
+		ee.SetInterruptLine(RST75, true)
+		ee.i85.masks = mask75
+
+		var saved bytes.Buffer
+		assert.Nil(t, ee.SaveSnapshot(&saved))
+
+		restored, restoredLatch := newSnapshotted(WithVariant(Intel8085))
+		err := restored.LoadSnapshot(bytes.NewReader(saved.Bytes()))
+		assert.Nil(t, err)
+		assert.Equal(t, ee.state(), restored.state(), "restores CPU state")
+		assert.Equal(t, ee.intRequest, restored.intRequest, "restores pending interrupt")
+		assert.Equal(t, ee.mem, restored.mem, "restores memory")
+		assert.Equal(t, lt.val, restoredLatch.val, "restores peripherals")
+
+		for i := 0; i < 50; i++ {
+			ee.Step()
+			restored.Step()
+		}
+		var original, continued bytes.Buffer
+		ee.SaveSnapshot(&original)
+		restored.SaveSnapshot(&continued)
+		assert.Equal(t, original.Bytes(), continued.Bytes(), "continues exactly like the original")
+	})
+
+	t.Run("with memory map", func(t *testing.T) {
+		newMapped := func() *CPU {
+			mp := NewMap()
+			mp.Attach(0x0000, 0x1fff, ROM(countingProgram))
+			mp.Attach(0x2000, 0x3fff, Mirror(NewRAM(0x400), 0x400))
+			ee, _ := newSnapshotted(WithBus(mp))
+			ee.sp = 0x2400
+			return ee
+		}
+
+		ee := newMapped()
+		for i := 0; i < 20; i++ {
+			ee.Step()
+		}
+
+		var saved bytes.Buffer
+		assert.Nil(t, ee.SaveSnapshot(&saved))
+
+		restored := newMapped()
+		assert.Nil(t, restored.LoadSnapshot(&saved))
+		assert.Equal(t, ee.ReadMemory(0x2000), restored.ReadMemory(0x2000), "restores RAM regions")
+		assert.NotZero(t, restored.ReadMemory(0x2000))
+	})
+
+	t.Run("when memory bus doesn't support snapshots", func(t *testing.T) {
+		ee := New(WithBus(Callback{}))
+
+		assert.NotNil(t, ee.SaveSnapshot(&bytes.Buffer{}))
+		assert.NotNil(t, ee.LoadSnapshot(&bytes.Buffer{}))
+	})
+
+	t.Run("when snapshot doesn't match CPU", func(t *testing.T) {
+		ee, _ := newSnapshotted()
+		var saved bytes.Buffer
+		ee.SaveSnapshot(&saved)
+
+		err := New(WithVariant(Intel8085)).LoadSnapshot(bytes.NewReader(saved.Bytes()))
+		assert.NotNil(t, err, "refuses other variant")
+
+		err = New().LoadSnapshot(bytes.NewReader(saved.Bytes()))
+		assert.NotNil(t, err, "refuses different peripherals")
+
+		err = New(WithBus(NewRAM(0x100))).LoadSnapshot(bytes.NewReader(saved.Bytes()))
+		assert.NotNil(t, err, "refuses different memory size")
+	})
+
+	t.Run("when file isn't a snapshot", func(t *testing.T) {
+		err := New().LoadSnapshot(bytes.NewReader([]byte("8080SNIP and some more bytes")))
+		assert.EqualError(t, err, "not a snapshot")
+	})
+}
@@ -0,0 +1,70 @@
+package eighty_eighty
+
+import (
+	"fmt"
+	"io/ioutil"
+	"regexp"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// TestSpec checks emulator against the instruction set specification; every operand points to the
+// next instruction, so jumps, calls and returns land where the next instruction starts either way
+func TestSpec(t *testing.T) {
+	for _, variant := range []Variant{Intel8080, Intel8085} {
+		for opCode, op := range instructionSets[variant] {
+			if op.Mnemonic == "RST" {
+				continue
+			}
+
+			t.Run(fmt.Sprintf("%d %#02x %s", variant, opCode, op.Name()), func(t *testing.T) {
+				const start = 0x1000
+				next := uint16(start + op.Size)
+
+				ee := New(WithVariant(variant))
+				ee.Load(start, []byte{uint8(opCode), uint8(next), uint8(next >> 8)})
+				ee.pc = start
+				ee.sp = 0x2000
+				ee.push16(next)
+				ee.setPair(hl, next)
+
+				spent, err := ee.Emulate()
+				if op.Undocumented && variant == Intel8085 {
+					assert.NotNil(t, err, "fails on undocumented opcode")
+					return
+				}
+
+				assert.Nil(t, err)
+				assert.Equal(t, next, ee.pc, "takes as many bytes as specified")
+				assert.Contains(t, []int{op.Cycles, op.CyclesTaken}, spent, "takes specified cycles")
+			})
+		}
+	}
+}
+
+// caseComment matches emulator switch cases along with comments naming the instruction, e.g.
+// "case 0x0e: // MVI C, D8"; comment may be followed by notes after a semicolon
+var caseComment = regexp.MustCompile(`(?m)^\tcase 0x([0-9a-f]{2}): // ([^;\n]*)`)
+
+// TestSpecComments keeps instruction names in comments of the emulator switch in line with the
+// specification, so fixing instruction's description can't leave a stale copy behind
+func TestSpecComments(t *testing.T) {
+	source, err := ioutil.ReadFile("eighty_eighty.go")
+	if err != nil {
+		t.Fatalf("cant read emulator source: %s", err.Error())
+	}
+
+	matches := caseComment.FindAllStringSubmatch(string(source), -1)
+	assert.Len(t, matches, 256, "names every opcode")
+
+	for _, match := range matches {
+		opCode, _ := strconv.ParseUint(match[1], 16, 8)
+		name := strings.TrimSuffix(strings.TrimSpace(match[2]), ", undocumented")
+		name = strings.Replace(name, ", ", ",", -1)
+
+		assert.Equal(t, instructionSets[Intel8080][opCode].Name(), name, "names opcode %#02x as spec does", opCode)
+	}
+}
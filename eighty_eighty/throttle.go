@@ -0,0 +1,118 @@
+package eighty_eighty
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// DefaultClock is a clock frequency of the original 8080 in Hz
+	DefaultClock = 2000000
+	// Unlimited clock makes Throttle run CPU as fast as possible
+	Unlimited = 0
+
+	defaultBatch = 10 * time.Millisecond
+)
+
+// Processor is a CPU Throttle is able to pace
+type Processor interface {
+	Step() (int, error)
+	Cycles() uint64
+	// Idle reports whether processor is halted with no interrupt to wake it up
+	Idle() bool
+}
+
+// Throttle runs CPU paced to provided clock frequency. It executes instructions in batches worth
+// of Batch wall-clock time and sleeps between them whenever emulation gets ahead of real time.
+type Throttle struct {
+	Batch time.Duration
+
+	cpu         Processor
+	clock       int
+	started     bool
+	start       time.Time
+	startCycles uint64
+
+	now   func() time.Time
+	sleep func(time.Duration)
+}
+
+// NewThrottle returns throttle running provided CPU at clock Hz; use Unlimited to disable pacing
+func NewThrottle(cpu Processor, clock int) (*Throttle, error) {
+	if clock < 0 {
+		return nil, fmt.Errorf("clock must be positive or Unlimited, got %d", clock)
+	}
+
+	return &Throttle{
+		Batch: defaultBatch,
+		cpu:   cpu,
+		clock: clock,
+		now:   time.Now,
+		sleep: time.Sleep,
+	}, nil
+}
+
+// Run executes batches until CPU halts with no interrupt to wake it up or an instruction fails
+func (t *Throttle) Run() error {
+	for {
+		if err := t.RunBatch(); err != nil {
+			return err
+		}
+		if t.cpu.Idle() {
+			return nil
+		}
+	}
+}
+
+// RunBatch executes instructions worth of a single batch and waits until wall clock catches up
+func (t *Throttle) RunBatch() error {
+	if !t.started {
+		t.started = true
+		t.start = t.now()
+		t.startCycles = t.cpu.Cycles()
+	}
+
+	target := t.cpu.Cycles() + t.batchCycles()
+	for t.cpu.Cycles() < target {
+		if _, err := t.cpu.Step(); err != nil {
+			return err
+		}
+	}
+
+	if t.clock == Unlimited {
+		return nil
+	}
+
+	expected := time.Duration(float64(t.cpu.Cycles()-t.startCycles) / float64(t.clock) * float64(time.Second))
+	if ahead := expected - t.now().Sub(t.start); ahead > 0 {
+		t.sleep(ahead)
+	}
+
+	return nil
+}
+
+// MHz returns effective clock frequency since the first batch
+func (t *Throttle) MHz() float64 {
+	if !t.started {
+		return 0
+	}
+
+	elapsed := t.now().Sub(t.start).Seconds()
+	if elapsed == 0 {
+		return 0
+	}
+
+	return float64(t.cpu.Cycles()-t.startCycles) / elapsed / 1e6
+}
+
+func (t *Throttle) batchCycles() uint64 {
+	clock := t.clock
+	if clock == Unlimited {
+		clock = DefaultClock
+	}
+
+	if batch := uint64(float64(clock) * t.Batch.Seconds()); batch > 0 {
+		return batch
+	}
+	return 1
+}
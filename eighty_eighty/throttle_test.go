@@ -0,0 +1,93 @@
+package eighty_eighty
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeClock struct {
+	current time.Time
+	slept   []time.Duration
+}
+
+func (fc *fakeClock) now() time.Time {
+	return fc.current
+}
+
+func (fc *fakeClock) sleep(d time.Duration) {
+	fc.slept = append(fc.slept, d)
+	fc.current = fc.current.Add(d)
+}
+
+func newThrottledLoop(clock int) (*Throttle, *CPU, *fakeClock) {
+	cpu := New()
+	cpu.Load(0, []byte{0xc3, 0x00, 0x00}) // JMP 0
+
+	fc := &fakeClock{current: time.Unix(0, 0)}
+	throttle, _ := NewThrottle(cpu, clock)
+	throttle.Batch = 100 * time.Millisecond
+	throttle.now = fc.now
+	throttle.sleep = fc.sleep
+
+	return throttle, cpu, fc
+}
+
+func TestThrottle(t *testing.T) {
+	t.Run("running a batch", func(t *testing.T) {
+		throttle, cpu, fc := newThrottledLoop(1000)
+
+		err := throttle.RunBatch()
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(100), cpu.Cycles(), "executes cycles worth of a batch")
+		assert.Equal(t, []time.Duration{100 * time.Millisecond}, fc.slept, "sleeps until wall clock catches up")
+		assert.InDelta(t, 0.001, throttle.MHz(), 1e-9, "reports effective frequency")
+	})
+
+	t.Run("when emulation is behind wall clock", func(t *testing.T) {
+		throttle, _, fc := newThrottledLoop(1000)
+
+		err := throttle.RunBatch()
+		assert.Nil(t, err)
+		fc.current = fc.current.Add(time.Second)
+
+		err = throttle.RunBatch()
+		assert.Nil(t, err)
+		assert.Len(t, fc.slept, 1, "does not sleep")
+	})
+
+	t.Run("when clock is unlimited", func(t *testing.T) {
+		throttle, cpu, fc := newThrottledLoop(Unlimited)
+
+		err := throttle.RunBatch()
+		assert.Nil(t, err)
+		assert.Equal(t, uint64(DefaultClock/10), cpu.Cycles(), "executes default clock worth of a batch")
+		assert.Empty(t, fc.slept, "never sleeps")
+	})
+
+	t.Run("when instruction fails", func(t *testing.T) {
+		throttle, cpu, _ := newThrottledLoop(1000)
+		cpu.undocumented = TrapUndocumented
+		cpu.Load(0, []byte{0x08})
+
+		err := throttle.Run()
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when clock is negative", func(t *testing.T) {
+		throttle, err := NewThrottle(New(), -1)
+		assert.EqualError(t, err, "clock must be positive or Unlimited, got -1")
+		assert.Nil(t, throttle)
+	})
+
+	t.Run("when CPU halts", func(t *testing.T) {
+		throttle, cpu, _ := newThrottledLoop(1000)
+		cpu.Load(0, []byte{0x76})
+
+		err := throttle.Run()
+		assert.Nil(t, err)
+		assert.True(t, cpu.Halted(), "stops running")
+		assert.GreaterOrEqual(t, cpu.Cycles(), uint64(100), "lets time pass till the end of the batch")
+	})
+}
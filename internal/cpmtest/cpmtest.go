@@ -0,0 +1,92 @@
+// Package cpmtest runs CP/M programs, such as CPU diagnostics and exercisers, in tests of emulated
+// processors
+package cpmtest
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+const (
+	programStart = 0x0100
+	bdos         = 0x0005
+	warmBoot     = 0x0000
+	memoryTop    = 0xf000 // reported to programs as the top of memory available to them
+
+	bdosPrintChar   = 2
+	bdosPrintString = 9
+
+	// RequireEnv names environment variable which makes missing programs fail tests instead of
+	// skipping them, for runs which fetched programs and must not silently lose them
+	RequireEnv = "CPM_PROGRAMS_REQUIRED"
+)
+
+// CPU is a processor able to run CP/M programs
+type CPU interface {
+	Load(address uint16, data []byte) error
+	ReadMemory(address uint16) uint8
+	WriteMemory(address uint16, val uint8)
+	SetPC(val uint16)
+	PC() uint16
+	Cycles() uint64
+	C() uint8
+	E() uint8
+	DE() uint16
+	Step() (int, error)
+}
+
+// Program reads program from provided file; programs aren't distributed with the repository, so
+// the test is skipped when the file is missing, unless RequireEnv is set
+func Program(t testing.TB, path string) []byte {
+	program, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		if os.Getenv(RequireEnv) != "" {
+			t.Fatalf("%s not found", path)
+		}
+		t.Skipf("%s not found, see %s", path, filepath.Join(filepath.Dir(path), "README.md"))
+	}
+	if err != nil {
+		t.Fatalf("cant read program: %s", err.Error())
+	}
+
+	return program
+}
+
+// Run runs CP/M .COM program with BDOS console calls stubbed and returns everything it printed;
+// cycle limit only catches runaway programs
+func Run(t testing.TB, cpu CPU, program []byte, limit uint64) string {
+	if err := cpu.Load(programStart, program); err != nil {
+		t.Fatalf("cant load program: %s", err.Error())
+	}
+	cpu.WriteMemory(bdos, 0xc9) // RET back to the program after serving BDOS call
+	cpu.WriteMemory(bdos+1, memoryTop&0xff)
+	cpu.WriteMemory(bdos+2, memoryTop>>8)
+	cpu.SetPC(programStart)
+
+	var output strings.Builder
+	for cpu.Cycles() < limit {
+		switch cpu.PC() {
+		case warmBoot:
+			return output.String()
+		case bdos:
+			switch cpu.C() {
+			case bdosPrintChar:
+				output.WriteByte(cpu.E())
+			case bdosPrintString:
+				for address := cpu.DE(); cpu.ReadMemory(address) != '$'; address++ {
+					output.WriteByte(cpu.ReadMemory(address))
+				}
+			}
+		}
+
+		if _, err := cpu.Step(); err != nil {
+			t.Fatalf("program failed at %#04x: %s\noutput so far:\n%s", cpu.PC(), err.Error(), output.String())
+		}
+	}
+
+	t.Fatalf("program did not finish in %d cycles\noutput so far:\n%s", limit, output.String())
+	return ""
+}
@@ -0,0 +1,149 @@
+// Package invaders wires the 8080 core to Taito Space Invaders arcade hardware
+package invaders
+
+import (
+	"fmt"
+	"io/ioutil"
+	"path/filepath"
+
+	"github.com/piokaczm/8080-emulator/eighty_eighty"
+)
+
+const (
+	// Clock is a frequency the machine's 8080 runs at
+	Clock = eighty_eighty.DefaultClock
+	// FrameCycles pass during every frame of 60Hz video
+	FrameCycles = Clock / 60
+
+	romSize     = 0x2000
+	romFileSize = 0x0800
+	ramStart    = 0x2000
+	ramSize     = 0x2000
+	videoStart  = 0x0400 // offset of video RAM within RAM
+	addressBits = 0x4000 // A14 and A15 aren't decoded, so everything repeats every 16K
+)
+
+// ROMFiles lists files making up ROM in order of addresses they are loaded at
+var ROMFiles = []string{"invaders.h", "invaders.g", "invaders.f", "invaders.e"}
+
+// Machine is Space Invaders hardware: 8K ROM, 1K of work RAM followed by 7K of video RAM, shift
+// register and inputs attached to ports, and video circuitry interrupting CPU twice every frame
+type Machine struct {
+	cpu   *eighty_eighty.CPU
+	ram   eighty_eighty.RAM
+	ports *ports
+}
+
+// Option configures Machine created with New
+type Option func(*Machine)
+
+// WithDIPs sets DIP switches read from port 2: bits 0 and 1 set number of ships, bit 3 makes extra
+// ship come at 1000 instead of 1500 points and bit 7 hides coin info
+func WithDIPs(dips uint8) Option {
+	return func(m *Machine) {
+		m.ports.inputs[2] = m.ports.inputs[2]&^dipBits | dips&dipBits
+	}
+}
+
+// WithSound registers function called with values written to sound ports 3 and 5
+func WithSound(fn func(port, val uint8)) Option {
+	return func(m *Machine) {
+		m.ports.sound = fn
+	}
+}
+
+// New returns machine running provided 8K ROM, see LoadROM
+func New(rom []byte, opts ...Option) (*Machine, error) {
+	if len(rom) != romSize {
+		return nil, fmt.Errorf("ROM has %d bytes instead of %d", len(rom), romSize)
+	}
+
+	m := &Machine{
+		ram:   eighty_eighty.NewRAM(ramSize),
+		ports: newPorts(),
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	mem := eighty_eighty.NewMap()
+	if err := mem.Attach(0, romSize-1, eighty_eighty.ROM(rom)); err != nil {
+		return nil, err
+	}
+	if err := mem.Attach(ramStart, ramStart+ramSize-1, m.ram); err != nil {
+		return nil, err
+	}
+
+	m.cpu = eighty_eighty.New(
+		eighty_eighty.WithBus(eighty_eighty.Mirror(mem, addressBits)),
+		eighty_eighty.WithPorts(0, 6, m.ports),
+	)
+	return m, nil
+}
+
+// LoadROM reads ROM files from provided directory and joins them into 8K ROM
+func LoadROM(dir string) ([]byte, error) {
+	rom := make([]byte, 0, romSize)
+	for _, name := range ROMFiles {
+		data, err := ioutil.ReadFile(filepath.Join(dir, name))
+		if err != nil {
+			return nil, err
+		}
+		if len(data) != romFileSize {
+			return nil, fmt.Errorf("%s has %d bytes instead of %d", name, len(data), romFileSize)
+		}
+
+		rom = append(rom, data...)
+	}
+
+	return rom, nil
+}
+
+// RunFrame runs CPU for a single frame; video circuitry requests RST 1 when the beam reaches the
+// middle of the screen and RST 2 when it reaches the bottom, at the start of vertical blank
+func (m *Machine) RunFrame() error {
+	frame := m.cpu.Cycles() / FrameCycles
+
+	if err := m.runUntil(frame*FrameCycles + FrameCycles/2); err != nil {
+		return err
+	}
+	m.cpu.Interrupt(eighty_eighty.RST(1))
+
+	if err := m.runUntil((frame + 1) * FrameCycles); err != nil {
+		return err
+	}
+	m.cpu.Interrupt(eighty_eighty.RST(2))
+
+	return nil
+}
+
+func (m *Machine) runUntil(cycles uint64) error {
+	for m.cpu.Cycles() < cycles {
+		if _, err := m.cpu.Step(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// Press holds provided button down
+func (m *Machine) Press(b Button) {
+	m.ports.inputs[b.port] |= b.bit
+}
+
+// Release lets provided button go
+func (m *Machine) Release(b Button) {
+	m.ports.inputs[b.port] &^= b.bit
+}
+
+// CPU returns the machine's processor, e.g. to save snapshots or record inputs
+func (m *Machine) CPU() *eighty_eighty.CPU {
+	return m.cpu
+}
+
+// VideoRAM returns 7K of video memory; every byte holds 8 pixels, lowest bit first, of a 256 pixels
+// wide line, and 224 lines make a frame, which the monitor shows rotated 90 degrees counterclockwise
+func (m *Machine) VideoRAM() []byte {
+	return m.ram[videoStart:]
+}
@@ -0,0 +1,158 @@
+package invaders
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newMachine returns machine running provided code placed at the start of ROM
+func newMachine(t *testing.T, code map[uint16][]byte, opts ...Option) *Machine {
+	rom := make([]byte, romSize)
+	for address, data := range code {
+		copy(rom[address:], data)
+	}
+
+	m, err := New(rom, opts...)
+	if err != nil {
+		t.Fatalf("cant create machine: %s", err.Error())
+	}
+	return m
+}
+
+func TestMemory(t *testing.T) {
+	m := newMachine(t, map[uint16][]byte{0x0000: {0x3e, 0x01}})
+	cpu := m.CPU()
+
+	cpu.WriteMemory(0x0000, 0xaa)
+	assert.Equal(t, uint8(0x3e), cpu.ReadMemory(0x0000), "ROM ignores writes")
+
+	cpu.WriteMemory(0x2000, 0x11)
+	assert.Equal(t, uint8(0x11), cpu.ReadMemory(0x6000), "mirrors RAM")
+	assert.Equal(t, uint8(0x3e), cpu.ReadMemory(0x4000), "mirrors ROM")
+
+	cpu.WriteMemory(0x2400, 0x33)
+	assert.Equal(t, uint8(0x33), m.VideoRAM()[0], "video RAM starts at 0x2400")
+	assert.Equal(t, 0x1c00, len(m.VideoRAM()))
+}
+
+func TestShiftRegister(t *testing.T) {
+	m := newMachine(t, map[uint16][]byte{0x0000: {
+		0x3e, 0xaa, // MVI A,0xaa
+		0xd3, 0x04, // OUT 4
+		0x3e, 0xff, // MVI A,0xff
+		0xd3, 0x04, // OUT 4
+		0x3e, 0x03, // MVI A,3
+		0xd3, 0x02, // OUT 2
+		0xdb, 0x03, // IN 3
+		0x76, // HLT
+	}})
+
+	assert.Nil(t, m.CPU().Run())
+	assert.Equal(t, uint8(0xfd), m.CPU().A(), "reads 0xffaa shifted left by 3 bits")
+}
+
+func TestInputs(t *testing.T) {
+	m := newMachine(t, map[uint16][]byte{0x0000: {
+		0xdb, 0x01, // IN 1
+		0x47,       // MOV B,A
+		0xdb, 0x02, // IN 2
+		0x76, // HLT
+	}}, WithDIPs(0x03))
+
+	m.Press(Coin)
+	m.Press(P1Left)
+	m.Press(P2Fire)
+	m.Release(P1Left)
+
+	assert.Nil(t, m.CPU().Run())
+	assert.Equal(t, uint8(0x09), m.CPU().B(), "reads coin and bit always set on port 1")
+	assert.Equal(t, uint8(0x13), m.CPU().A(), "reads player 2 and DIP switches on port 2")
+}
+
+func TestSound(t *testing.T) {
+	var played []uint8
+	m := newMachine(t, map[uint16][]byte{0x0000: {
+		0x3e, 0x01, // MVI A,1
+		0xd3, 0x03, // OUT 3
+		0xd3, 0x05, // OUT 5
+		0x76, // HLT
+	}}, WithSound(func(port, val uint8) { played = append(played, port, val) }))
+
+	assert.Nil(t, m.CPU().Run())
+	assert.Equal(t, []uint8{3, 1, 5, 1}, played)
+}
+
+func TestRunFrame(t *testing.T) {
+	m := newMachine(t, map[uint16][]byte{
+		0x0000: {
+			0x31, 0x00, 0x24, // LXI SP,0x2400
+			0xfb,             // EI
+			0xc3, 0x04, 0x00, // JMP 0x0004
+		},
+		0x0008: {0x21, 0x00, 0x20, 0xc3, 0x20, 0x00}, // LXI H,0x2000; JMP count
+		0x0010: {0x21, 0x01, 0x20, 0xc3, 0x20, 0x00}, // LXI H,0x2001; JMP count
+		0x0020: {0x34, 0xfb, 0xc9},                   // count: INR M; EI; RET
+	})
+	cpu := m.CPU()
+
+	for frame := 0; frame < 3; frame++ {
+		assert.Nil(t, m.RunFrame())
+	}
+	assert.Equal(t, uint8(3), cpu.ReadMemory(0x2000), "serves RST 1 in the middle of every frame")
+	assert.Equal(t, uint8(2), cpu.ReadMemory(0x2001), "serves RST 2 at the end of every frame")
+	assert.True(t, cpu.Cycles() >= 3*FrameCycles)
+	assert.True(t, cpu.Cycles() < 3*FrameCycles+20, "doesn't drift from frame boundaries")
+
+	t.Run("snapshot", func(t *testing.T) {
+		m.Press(Coin)
+		var saved bytes.Buffer
+		assert.Nil(t, cpu.SaveSnapshot(&saved))
+
+		restored := newMachine(t, nil)
+		assert.Nil(t, restored.CPU().LoadSnapshot(&saved))
+		assert.Equal(t, m.ports.inputs, restored.ports.inputs, "restores inputs")
+		assert.Equal(t, uint8(3), restored.CPU().ReadMemory(0x2000), "restores RAM")
+	})
+}
+
+func TestLoadROM(t *testing.T) {
+	dir, err := ioutil.TempDir("", "invaders")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	for i, name := range ROMFiles {
+		ioutil.WriteFile(filepath.Join(dir, name), bytes.Repeat([]byte{uint8(i)}, romFileSize), 0644)
+	}
+
+	rom, err := LoadROM(dir)
+	assert.Nil(t, err)
+	assert.Equal(t, romSize, len(rom))
+	assert.Equal(t, uint8(0), rom[0x0000], "starts with invaders.h")
+	assert.Equal(t, uint8(3), rom[0x1800], "ends with invaders.e")
+
+	t.Run("when file has wrong size", func(t *testing.T) {
+		ioutil.WriteFile(filepath.Join(dir, "invaders.f"), []byte{0x00}, 0644)
+
+		_, err := LoadROM(dir)
+		assert.NotNil(t, err)
+	})
+
+	t.Run("when file is missing", func(t *testing.T) {
+		os.Remove(filepath.Join(dir, "invaders.g"))
+
+		_, err := LoadROM(dir)
+		assert.NotNil(t, err)
+	})
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(make([]byte, 0x1000))
+	assert.NotNil(t, err, "refuses ROM of wrong size")
+}
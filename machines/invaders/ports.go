@@ -0,0 +1,107 @@
+package invaders
+
+import (
+	"encoding/binary"
+	"io"
+)
+
+// port numbers, IN and OUT use them for different devices
+const (
+	portInputs0 = 0
+	portInputs1 = 1
+	portInputs2 = 2
+	portShift   = 3 // IN reads shifted value
+	portOffset  = 2 // OUT sets shift offset
+	portData    = 4 // OUT shifts value in
+	portSound1  = 3
+	portSound2  = 5
+)
+
+// dipBits are bits of port 2 set by DIP switches
+const dipBits = 0x8b
+
+// Button is one of the machine's inputs
+type Button struct {
+	port uint8
+	bit  uint8
+}
+
+// buttons and switches as seen on input ports; all of them are active high
+var (
+	Coin    = Button{portInputs1, 1 << 0}
+	P2Start = Button{portInputs1, 1 << 1}
+	P1Start = Button{portInputs1, 1 << 2}
+	P1Fire  = Button{portInputs1, 1 << 4}
+	P1Left  = Button{portInputs1, 1 << 5}
+	P1Right = Button{portInputs1, 1 << 6}
+	Tilt    = Button{portInputs2, 1 << 2}
+	P2Fire  = Button{portInputs2, 1 << 4}
+	P2Left  = Button{portInputs2, 1 << 5}
+	P2Right = Button{portInputs2, 1 << 6}
+)
+
+// ports holds state of devices attached to the machine's ports: inputs and the shift register,
+// which shifts 16bit value by up to 7 bits at once since 8080 can't do that quickly
+type ports struct {
+	inputs [3]uint8
+	shift  uint16
+	offset uint8
+	sound  func(port, val uint8)
+}
+
+func newPorts() *ports {
+	return &ports{
+		inputs: [3]uint8{
+			0x0e, // bits 1-3 are always set
+			0x08, // bit 3 is always set
+			0x00,
+		},
+	}
+}
+
+func (p *ports) In(port uint8) uint8 {
+	switch port {
+	case portInputs0, portInputs1, portInputs2:
+		return p.inputs[port]
+	case portShift:
+		return uint8(p.shift >> (8 - p.offset))
+	}
+
+	return 0
+}
+
+func (p *ports) Out(port uint8, val uint8) {
+	switch port {
+	case portOffset:
+		p.offset = val & 0x07
+	case portData:
+		p.shift = uint16(val)<<8 | p.shift>>8
+	case portSound1, portSound2:
+		if p.sound != nil {
+			p.sound(port, val)
+		}
+	}
+	// port 6 is a watchdog, resetting the machine when it isn't written often enough
+}
+
+type portsState struct {
+	Inputs [3]uint8
+	Shift  uint16
+	Offset uint8
+}
+
+// Snapshot writes state of inputs and the shift register
+func (p *ports) Snapshot(w io.Writer) error {
+	return binary.Write(w, binary.LittleEndian, portsState{Inputs: p.inputs, Shift: p.shift, Offset: p.offset})
+}
+
+// Restore reads state saved with Snapshot
+func (p *ports) Restore(r io.Reader) error {
+	var state portsState
+	if err := binary.Read(r, binary.LittleEndian, &state); err != nil {
+		return err
+	}
+
+	p.inputs, p.shift, p.offset = state.Inputs, state.Shift, state.Offset
+	return nil
+}
@@ -2,29 +2,155 @@ package main
 
 import (
 	"flag"
+	"fmt"
+	"io"
 	"io/ioutil"
 	"log"
+	"os"
 
 	"github.com/piokaczm/8080-emulator/disassembler"
+	"github.com/piokaczm/8080-emulator/eighty_eighty"
+	"github.com/piokaczm/8080-emulator/z80"
 )
 
+// machine is a CPU a program can be loaded into and run on
+type machine interface {
+	eighty_eighty.Processor
+	Load(address uint16, data []byte) error
+	SetPC(val uint16)
+	PC() uint16
+}
+
+// snapshotter is a machine which state can be saved and restored
+type snapshotter interface {
+	SaveSnapshot(w io.Writer) error
+	LoadSnapshot(r io.Reader) error
+}
+
 func main() {
 	dFlag := flag.String("d", "", "use this flag to disassemble provided file")
+	rFlag := flag.String("r", "", "use this flag to run provided file")
+	orgFlag := flag.Uint("org", 0, "address the file is loaded at when running it")
+	clockFlag := flag.Int("clock", eighty_eighty.DefaultClock, "clock frequency in Hz, 0 runs unlimited")
+	trapFlag := flag.Bool("trap", false, "stop running on undocumented opcodes instead of executing them")
+	i8085Flag := flag.Bool("8085", false, "disassemble and run the file as 8085 code instead of 8080")
+	z80Flag := flag.Bool("z80", false, "disassemble and run the file as Z80 code instead of 8080")
+	restoreFlag := flag.String("restore", "", "resume running from provided snapshot instead of starting at org")
+	saveFlag := flag.String("save", "", "save snapshot of the machine to provided file when it stops running")
 	flag.Parse()
 
 	if len(*dFlag) > 0 {
-		disassemble(*dFlag)
+		disassemble(*dFlag, *i8085Flag, *z80Flag)
+	}
+
+	if len(*rFlag) > 0 {
+		cpu := newMachine(*trapFlag, *i8085Flag, *z80Flag)
+		run(*rFlag, uint16(*orgFlag), *clockFlag, cpu, *restoreFlag, *saveFlag)
+	}
+}
+
+func disassemble(path string, i8085, zilog bool) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		log.Fatal(err)
+	}
+
+	decode := disassembler.Decode
+	switch {
+	case zilog:
+		decode = disassembler.DecodeZ80
+	case i8085:
+		decode = disassembler.Decode8085
+	}
+
+	err = decode(data)
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+func newMachine(trap, i8085, zilog bool) machine {
+	if zilog {
+		return z80.New()
+	}
+
+	policy := eighty_eighty.ExecuteUndocumented
+	if trap {
+		policy = eighty_eighty.TrapUndocumented
+	}
+
+	variant := eighty_eighty.Intel8080
+	if i8085 {
+		variant = eighty_eighty.Intel8085
 	}
+
+	return eighty_eighty.New(eighty_eighty.WithUndocumented(policy), eighty_eighty.WithVariant(variant))
 }
 
-func disassemble(path string) {
+func run(path string, org uint16, clock int, cpu machine, restore, save string) {
+	throttle, err := eighty_eighty.NewThrottle(cpu, clock)
+	if err != nil {
+		log.Fatal(err)
+	}
+
 	data, err := ioutil.ReadFile(path)
 	if err != nil {
-		log.Fatalf(err.Error())
+		log.Fatal(err)
+	}
+
+	if err = cpu.Load(org, data); err != nil {
+		log.Fatal(err)
+	}
+	cpu.SetPC(org)
+
+	if restore != "" {
+		if err = restoreSnapshot(cpu, restore); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	err = throttle.Run()
+	log.Printf("stopped at %#04x after %d cycles, effective speed %.3f MHz", cpu.PC(), cpu.Cycles(), throttle.MHz())
+
+	if save != "" {
+		if err := saveSnapshot(cpu, save); err != nil {
+			log.Print(err)
+		}
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+}
+
+// saveSnapshot writes state of provided machine to a file
+func saveSnapshot(cpu machine, path string) error {
+	sn, ok := cpu.(snapshotter)
+	if !ok {
+		return fmt.Errorf("%T doesn't support snapshots", cpu)
+	}
+
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := sn.SaveSnapshot(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// restoreSnapshot loads state of provided machine from a file
+func restoreSnapshot(cpu machine, path string) error {
+	sn, ok := cpu.(snapshotter)
+	if !ok {
+		return fmt.Errorf("%T doesn't support snapshots", cpu)
 	}
 
-	err = disassembler.Decode(data)
+	file, err := os.Open(path)
 	if err != nil {
-		log.Fatalf(err.Error())
+		return err
 	}
+	defer file.Close()
+	return sn.LoadSnapshot(file)
 }
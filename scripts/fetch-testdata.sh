@@ -0,0 +1,29 @@
+#!/bin/sh
+# Fetches CP/M diagnostics, exercisers and community single step tests the CPU tests run, they
+# can't be distributed with the repository. Run from the repository root, then have missing programs fail tests with
+# CPM_PROGRAMS_REQUIRED=1 go test ./...
+set -eu
+
+fetch() {
+	echo "fetching $2"
+	curl -fsSL -o "$2" "$1"
+}
+
+i8080=https://raw.githubusercontent.com/superzazu/8080/master/cpu_tests
+for file in TST8080.COM CPUTEST.COM 8080PRE.COM 8080EXM.COM; do
+	fetch "$i8080/$file" "eighty_eighty/testdata/$file"
+done
+
+z80=https://raw.githubusercontent.com/anotherlin/z80emu/master/testfiles
+for file in zexdoc.com zexall.com; do
+	fetch "$z80/$file" "z80/testdata/$file"
+done
+
+# single step tests take hundreds of megabytes, opcodes which fail to download are left out and
+# the rest still runs
+sst=https://raw.githubusercontent.com/SingleStepTests/8080/main/v1
+mkdir -p eighty_eighty/testdata/sst8080
+for opcode in $(seq 0 255); do
+	file=$(printf "%02x.json" "$opcode")
+	fetch "$sst/$file" "eighty_eighty/testdata/sst8080/$file" || echo "skipping $file"
+done
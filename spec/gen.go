@@ -0,0 +1,225 @@
+//go:build ignore
+// +build ignore
+
+// gen generates i8080_gen.go from i8080.txt
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	"go/format"
+	"io/ioutil"
+	"log"
+	"os"
+	"strconv"
+	"strings"
+)
+
+const (
+	source = "i8080.txt"
+	target = "i8080_gen.go"
+)
+
+// mnemonics with B, D and H operands standing for registers pairs
+var pairMnemonics = map[string]bool{
+	"LXI": true, "STAX": true, "LDAX": true, "INX": true, "DCX": true, "DAD": true, "PUSH": true, "POP": true,
+}
+
+var flagNames = map[string]string{"Z": "FlagZ", "S": "FlagS", "P": "FlagP", "CY": "FlagCY", "AC": "FlagAC"}
+
+type row struct {
+	opCode       int
+	mnemonic     string
+	operands     []string
+	cycles       [2]string // not taken, taken
+	flags        []string
+	function     string
+	undocumented bool
+}
+
+func main() {
+	file, err := os.Open(source)
+	if err != nil {
+		log.Fatal(err)
+	}
+	defer file.Close()
+
+	var i8080, i8085 [256]*row
+	scanner := bufio.NewScanner(file)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+
+		r8080, r8085, err := parse(text)
+		if err != nil {
+			log.Fatalf("%s:%d: %s", source, line, err.Error())
+		}
+		if r8080 != nil {
+			if i8080[r8080.opCode] != nil {
+				log.Fatalf("%s:%d: opcode %02x listed twice for 8080", source, line, r8080.opCode)
+			}
+			i8080[r8080.opCode] = r8080
+		}
+		if r8085 != nil {
+			if i8085[r8085.opCode] != nil {
+				log.Fatalf("%s:%d: opcode %02x listed twice for 8085", source, line, r8085.opCode)
+			}
+			i8085[r8085.opCode] = r8085
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		log.Fatal(err)
+	}
+
+	var out bytes.Buffer
+	fmt.Fprintf(&out, "// Code generated by gen.go from %s; DO NOT EDIT.\n\npackage spec\n\n", source)
+	if err := writeTable(&out, "Intel8080", "8080", i8080); err != nil {
+		log.Fatal(err)
+	}
+	if err := writeTable(&out, "Intel8085", "8085", i8085); err != nil {
+		log.Fatal(err)
+	}
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		log.Fatal(err)
+	}
+	if err := ioutil.WriteFile(target, src, 0644); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// parse reads a single row, which describes opcode on both CPUs unless cycles of one of them are -
+func parse(text string) (*row, *row, error) {
+	columns := strings.Split(text, "|")
+	for len(columns) < 7 {
+		columns = append(columns, "")
+	}
+	for i := range columns {
+		columns[i] = strings.TrimSpace(columns[i])
+	}
+
+	opCode, err := strconv.ParseUint(columns[0], 16, 8)
+	if err != nil {
+		return nil, nil, fmt.Errorf("bad opcode %q", columns[0])
+	}
+
+	base := row{
+		opCode:       int(opCode),
+		mnemonic:     strings.TrimPrefix(columns[1], "*"),
+		undocumented: strings.HasPrefix(columns[1], "*"),
+		function:     columns[6],
+	}
+	if columns[2] != "" {
+		base.operands = strings.Split(columns[2], ",")
+	}
+	if columns[5] != "" {
+		for _, flag := range strings.Split(columns[5], ",") {
+			name, ok := flagNames[strings.TrimSpace(flag)]
+			if !ok {
+				return nil, nil, fmt.Errorf("unknown flag %q", flag)
+			}
+			base.flags = append(base.flags, name)
+		}
+	}
+
+	var r8080 *row
+	if columns[3] != "-" {
+		r8080 = &row{}
+		*r8080 = base
+		if r8080.cycles, err = parseCycles(columns[3]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	var r8085 *row
+	if columns[4] != "-" {
+		r8085 = &row{}
+		*r8085 = base
+		if r8085.cycles, err = parseCycles(columns[4]); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	if r8080 == nil && r8085 == nil {
+		return nil, nil, fmt.Errorf("row describes opcode on neither CPU")
+	}
+	return r8080, r8085, nil
+}
+
+func parseCycles(text string) ([2]string, error) {
+	parts := strings.Split(text, "/")
+	for _, part := range parts {
+		if _, err := strconv.Atoi(part); err != nil {
+			return [2]string{}, fmt.Errorf("bad cycles %q", text)
+		}
+	}
+
+	if len(parts) == 1 {
+		return [2]string{parts[0], parts[0]}, nil
+	}
+	return [2]string{parts[0], parts[1]}, nil
+}
+
+func writeTable(out *bytes.Buffer, name, cpu string, rows [256]*row) error {
+	fmt.Fprintf(out, "// %s lists all opcodes of %s indexed with their value\n", name, cpu)
+	fmt.Fprintf(out, "var %s = [256]Opcode{\n", name)
+
+	for opCode, r := range rows {
+		if r == nil {
+			return fmt.Errorf("opcode %02x missing for %s", opCode, cpu)
+		}
+
+		size := 1
+		var operands []string
+		for _, operand := range r.operands {
+			kind, bytes := operandKind(r.mnemonic, operand)
+			size += bytes
+			operands = append(operands, fmt.Sprintf("{%s, %q}", kind, operand))
+		}
+
+		fmt.Fprintf(out, "\t0x%02x: {Mnemonic: %q, ", opCode, r.mnemonic)
+		if len(operands) > 0 {
+			fmt.Fprintf(out, "Operands: []Operand{%s}, ", strings.Join(operands, ", "))
+		}
+		fmt.Fprintf(out, "Size: %d, Cycles: %s, CyclesTaken: %s", size, r.cycles[0], r.cycles[1])
+		if len(r.flags) > 0 {
+			fmt.Fprintf(out, ", Flags: %s", strings.Join(r.flags, " | "))
+		}
+		if r.function != "" {
+			fmt.Fprintf(out, ", Function: %q", r.function)
+		}
+		if r.undocumented {
+			fmt.Fprint(out, ", Undocumented: true")
+		}
+		fmt.Fprint(out, "},\n")
+	}
+
+	fmt.Fprint(out, "}\n\n")
+	return nil
+}
+
+// operandKind returns kind of operand and number of bytes it takes after the opcode
+func operandKind(mnemonic, operand string) (string, int) {
+	switch operand {
+	case "D8":
+		return "Data8", 1
+	case "D16":
+		return "Data16", 2
+	case "adr":
+		return "Address", 2
+	case "SP", "PSW":
+		return "RegisterPair", 0
+	}
+
+	if mnemonic == "RST" {
+		return "Restart", 0
+	}
+	if pairMnemonics[mnemonic] {
+		return "RegisterPair", 0
+	}
+	return "Register", 0
+}
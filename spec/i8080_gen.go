@@ -0,0 +1,523 @@
+// Code generated by gen.go from i8080.txt; DO NOT EDIT.
+
+package spec
+
+// Intel8080 lists all opcodes of 8080 indexed with their value
+var Intel8080 = [256]Opcode{
+	0x00: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4},
+	0x01: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "B"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "B <- byte 3, C <- byte 2"},
+	0x02: {Mnemonic: "STAX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(BC) <- A"},
+	0x03: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "BC <- BC+1"},
+	0x04: {Mnemonic: "INR", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "B <- B+1"},
+	0x05: {Mnemonic: "DCR", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "B <- B-1"},
+	0x06: {Mnemonic: "MVI", Operands: []Operand{{Register, "B"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "B <- byte 2"},
+	0x07: {Mnemonic: "RLC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A << 1; bit 0 = prev bit 7; CY = prev bit 7"},
+	0x08: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x09: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + BC"},
+	0x0a: {Mnemonic: "LDAX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "A <- (BC)"},
+	0x0b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "BC <- BC-1"},
+	0x0c: {Mnemonic: "INR", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "C <- C+1"},
+	0x0d: {Mnemonic: "DCR", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "C <- C-1"},
+	0x0e: {Mnemonic: "MVI", Operands: []Operand{{Register, "C"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "C <- byte 2"},
+	0x0f: {Mnemonic: "RRC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A >> 1; bit 7 = prev bit 0; CY = prev bit 0"},
+	0x10: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x11: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "D"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "D <- byte 3, E <- byte 2"},
+	0x12: {Mnemonic: "STAX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(DE) <- A"},
+	0x13: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "DE <- DE + 1"},
+	0x14: {Mnemonic: "INR", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "D <- D+1"},
+	0x15: {Mnemonic: "DCR", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "D <- D-1"},
+	0x16: {Mnemonic: "MVI", Operands: []Operand{{Register, "D"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "D <- byte 2"},
+	0x17: {Mnemonic: "RAL", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A << 1; bit 0 = prev CY; CY = prev bit 7"},
+	0x18: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x19: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + DE"},
+	0x1a: {Mnemonic: "LDAX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "A <- (DE)"},
+	0x1b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "DE <- DE-1"},
+	0x1c: {Mnemonic: "INR", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "E <- E+1"},
+	0x1d: {Mnemonic: "DCR", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "E <- E-1"},
+	0x1e: {Mnemonic: "MVI", Operands: []Operand{{Register, "E"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "E <- byte 2"},
+	0x1f: {Mnemonic: "RAR", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A >> 1; bit 7 = prev CY; CY = prev bit 0"},
+	0x20: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x21: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "H"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "H <- byte 3, L <- byte 2"},
+	0x22: {Mnemonic: "SHLD", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 16, CyclesTaken: 16, Function: "(adr) <- L; (adr+1) <- H"},
+	0x23: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "HL <- HL + 1"},
+	0x24: {Mnemonic: "INR", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "H <- H+1"},
+	0x25: {Mnemonic: "DCR", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "H <- H-1"},
+	0x26: {Mnemonic: "MVI", Operands: []Operand{{Register, "H"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "H <- byte 2"},
+	0x27: {Mnemonic: "DAA", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "decimal adjust A"},
+	0x28: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x29: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + HL"},
+	0x2a: {Mnemonic: "LHLD", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 16, CyclesTaken: 16, Function: "L <- (adr); H <- (adr+1)"},
+	0x2b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "HL <- HL-1"},
+	0x2c: {Mnemonic: "INR", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "L <- L+1"},
+	0x2d: {Mnemonic: "DCR", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "L <- L-1"},
+	0x2e: {Mnemonic: "MVI", Operands: []Operand{{Register, "L"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "L <- byte 2"},
+	0x2f: {Mnemonic: "CMA", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- !A"},
+	0x30: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x31: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "SP"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "SP.hi <- byte 3, SP.lo <- byte 2"},
+	0x32: {Mnemonic: "STA", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 13, CyclesTaken: 13, Function: "(adr) <- A"},
+	0x33: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "SP"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "SP <- SP+1"},
+	0x34: {Mnemonic: "INR", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "(HL) <- (HL)+1"},
+	0x35: {Mnemonic: "DCR", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "(HL) <- (HL)-1"},
+	0x36: {Mnemonic: "MVI", Operands: []Operand{{Register, "M"}, {Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "(HL) <- byte 2"},
+	0x37: {Mnemonic: "STC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "CY = 1"},
+	0x38: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "undocumented alias of NOP", Undocumented: true},
+	0x39: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "SP"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + SP"},
+	0x3a: {Mnemonic: "LDA", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 13, CyclesTaken: 13, Function: "A <- (adr)"},
+	0x3b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "SP"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "SP <- SP-1"},
+	0x3c: {Mnemonic: "INR", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "A <- A+1"},
+	0x3d: {Mnemonic: "DCR", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "A <- A-1"},
+	0x3e: {Mnemonic: "MVI", Operands: []Operand{{Register, "A"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "A <- byte 2"},
+	0x3f: {Mnemonic: "CMC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "CY = !CY"},
+	0x40: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- B"},
+	0x41: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- C"},
+	0x42: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- D"},
+	0x43: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- E"},
+	0x44: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- H"},
+	0x45: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- L"},
+	0x46: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "B <- (HL)"},
+	0x47: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "B <- A"},
+	0x48: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- B"},
+	0x49: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- C"},
+	0x4a: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- D"},
+	0x4b: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- E"},
+	0x4c: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- H"},
+	0x4d: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- L"},
+	0x4e: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "C <- (HL)"},
+	0x4f: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "C <- A"},
+	0x50: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- B"},
+	0x51: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- C"},
+	0x52: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- D"},
+	0x53: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- E"},
+	0x54: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- H"},
+	0x55: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- L"},
+	0x56: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "D <- (HL)"},
+	0x57: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "D <- A"},
+	0x58: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- B"},
+	0x59: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- C"},
+	0x5a: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- D"},
+	0x5b: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- E"},
+	0x5c: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- H"},
+	0x5d: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- L"},
+	0x5e: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "E <- (HL)"},
+	0x5f: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "E <- A"},
+	0x60: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- B"},
+	0x61: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- C"},
+	0x62: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- D"},
+	0x63: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- E"},
+	0x64: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- H"},
+	0x65: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- L"},
+	0x66: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "H <- (HL)"},
+	0x67: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "H <- A"},
+	0x68: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- B"},
+	0x69: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- C"},
+	0x6a: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- D"},
+	0x6b: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- E"},
+	0x6c: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- H"},
+	0x6d: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- L"},
+	0x6e: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "L <- (HL)"},
+	0x6f: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "L <- A"},
+	0x70: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "B"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- B"},
+	0x71: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "C"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- C"},
+	0x72: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "D"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- D"},
+	0x73: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "E"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- E"},
+	0x74: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "H"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- H"},
+	0x75: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "L"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- L"},
+	0x76: {Mnemonic: "HLT", Size: 1, Cycles: 7, CyclesTaken: 7, Function: "halt until interrupt"},
+	0x77: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "A"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- A"},
+	0x78: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "B"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- B"},
+	0x79: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "C"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- C"},
+	0x7a: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "D"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- D"},
+	0x7b: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "E"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- E"},
+	0x7c: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "H"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- H"},
+	0x7d: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "L"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- L"},
+	0x7e: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "A <- (HL)"},
+	0x7f: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "A"}}, Size: 1, Cycles: 5, CyclesTaken: 5, Function: "A <- A"},
+	0x80: {Mnemonic: "ADD", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + B"},
+	0x81: {Mnemonic: "ADD", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + C"},
+	0x82: {Mnemonic: "ADD", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + D"},
+	0x83: {Mnemonic: "ADD", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + E"},
+	0x84: {Mnemonic: "ADD", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + H"},
+	0x85: {Mnemonic: "ADD", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + L"},
+	0x86: {Mnemonic: "ADD", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + (HL)"},
+	0x87: {Mnemonic: "ADD", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + A"},
+	0x88: {Mnemonic: "ADC", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + B + CY"},
+	0x89: {Mnemonic: "ADC", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + C + CY"},
+	0x8a: {Mnemonic: "ADC", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + D + CY"},
+	0x8b: {Mnemonic: "ADC", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + E + CY"},
+	0x8c: {Mnemonic: "ADC", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + H + CY"},
+	0x8d: {Mnemonic: "ADC", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + L + CY"},
+	0x8e: {Mnemonic: "ADC", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + (HL) + CY"},
+	0x8f: {Mnemonic: "ADC", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + A + CY"},
+	0x90: {Mnemonic: "SUB", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - B"},
+	0x91: {Mnemonic: "SUB", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - C"},
+	0x92: {Mnemonic: "SUB", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - D"},
+	0x93: {Mnemonic: "SUB", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - E"},
+	0x94: {Mnemonic: "SUB", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - H"},
+	0x95: {Mnemonic: "SUB", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - L"},
+	0x96: {Mnemonic: "SUB", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - (HL)"},
+	0x97: {Mnemonic: "SUB", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - A"},
+	0x98: {Mnemonic: "SBB", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - B - CY"},
+	0x99: {Mnemonic: "SBB", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - C - CY"},
+	0x9a: {Mnemonic: "SBB", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - D - CY"},
+	0x9b: {Mnemonic: "SBB", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - E - CY"},
+	0x9c: {Mnemonic: "SBB", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - H - CY"},
+	0x9d: {Mnemonic: "SBB", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - L - CY"},
+	0x9e: {Mnemonic: "SBB", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - (HL) - CY"},
+	0x9f: {Mnemonic: "SBB", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - A - CY"},
+	0xa0: {Mnemonic: "ANA", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & B"},
+	0xa1: {Mnemonic: "ANA", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & C"},
+	0xa2: {Mnemonic: "ANA", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & D"},
+	0xa3: {Mnemonic: "ANA", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & E"},
+	0xa4: {Mnemonic: "ANA", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & H"},
+	0xa5: {Mnemonic: "ANA", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & L"},
+	0xa6: {Mnemonic: "ANA", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & (HL)"},
+	0xa7: {Mnemonic: "ANA", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & A"},
+	0xa8: {Mnemonic: "XRA", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ B"},
+	0xa9: {Mnemonic: "XRA", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ C"},
+	0xaa: {Mnemonic: "XRA", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ D"},
+	0xab: {Mnemonic: "XRA", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ E"},
+	0xac: {Mnemonic: "XRA", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ H"},
+	0xad: {Mnemonic: "XRA", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ L"},
+	0xae: {Mnemonic: "XRA", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ (HL)"},
+	0xaf: {Mnemonic: "XRA", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ A"},
+	0xb0: {Mnemonic: "ORA", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb1: {Mnemonic: "ORA", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb2: {Mnemonic: "ORA", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb3: {Mnemonic: "ORA", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb4: {Mnemonic: "ORA", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb5: {Mnemonic: "ORA", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb6: {Mnemonic: "ORA", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb7: {Mnemonic: "ORA", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb8: {Mnemonic: "CMP", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - B"},
+	0xb9: {Mnemonic: "CMP", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - C"},
+	0xba: {Mnemonic: "CMP", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - D"},
+	0xbb: {Mnemonic: "CMP", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - E"},
+	0xbc: {Mnemonic: "CMP", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - H"},
+	0xbd: {Mnemonic: "CMP", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - L"},
+	0xbe: {Mnemonic: "CMP", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - (HL)"},
+	0xbf: {Mnemonic: "CMP", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - A"},
+	0xc0: {Mnemonic: "RNZ", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if NZ, RET"},
+	0xc1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Function: "C <- (SP); B <- (SP+1); SP <- SP+2"},
+	0xc2: {Mnemonic: "JNZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if NZ, PC <- adr"},
+	0xc3: {Mnemonic: "JMP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "PC <- adr"},
+	0xc4: {Mnemonic: "CNZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if NZ, CALL adr"},
+	0xc5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "(SP-2) <- C; (SP-1) <- B; SP <- SP-2"},
+	0xc6: {Mnemonic: "ADI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + byte"},
+	0xc7: {Mnemonic: "RST", Operands: []Operand{{Restart, "0"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $0"},
+	0xc8: {Mnemonic: "RZ", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if Z, RET"},
+	0xc9: {Mnemonic: "RET", Size: 1, Cycles: 10, CyclesTaken: 10, Function: "PC.lo <- (SP); PC.hi <- (SP+1); SP <- SP+2"},
+	0xca: {Mnemonic: "JZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if Z, PC <- adr"},
+	0xcb: {Mnemonic: "JMP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "undocumented alias of JMP", Undocumented: true},
+	0xcc: {Mnemonic: "CZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if Z, CALL adr"},
+	0xcd: {Mnemonic: "CALL", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 17, CyclesTaken: 17, Function: "(SP-1) <- PC.hi; (SP-2) <- PC.lo; SP <- SP-2; PC <- adr"},
+	0xce: {Mnemonic: "ACI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + data + CY"},
+	0xcf: {Mnemonic: "RST", Operands: []Operand{{Restart, "1"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $8"},
+	0xd0: {Mnemonic: "RNC", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if NCY, RET"},
+	0xd1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Function: "E <- (SP); D <- (SP+1); SP <- SP+2"},
+	0xd2: {Mnemonic: "JNC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if NCY, PC <- adr"},
+	0xd3: {Mnemonic: "OUT", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "port <- A"},
+	0xd4: {Mnemonic: "CNC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if NCY, CALL adr"},
+	0xd5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "(SP-2) <- E; (SP-1) <- D; SP <- SP-2"},
+	0xd6: {Mnemonic: "SUI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - data"},
+	0xd7: {Mnemonic: "RST", Operands: []Operand{{Restart, "2"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $10"},
+	0xd8: {Mnemonic: "RC", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if CY, RET"},
+	0xd9: {Mnemonic: "RET", Size: 1, Cycles: 10, CyclesTaken: 10, Function: "undocumented alias of RET", Undocumented: true},
+	0xda: {Mnemonic: "JC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if CY, PC <- adr"},
+	0xdb: {Mnemonic: "IN", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "A <- port"},
+	0xdc: {Mnemonic: "CC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if CY, CALL adr"},
+	0xdd: {Mnemonic: "CALL", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 17, CyclesTaken: 17, Function: "undocumented alias of CALL", Undocumented: true},
+	0xde: {Mnemonic: "SBI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - data - CY"},
+	0xdf: {Mnemonic: "RST", Operands: []Operand{{Restart, "3"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $18"},
+	0xe0: {Mnemonic: "RPO", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if PO, RET"},
+	0xe1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Function: "L <- (SP); H <- (SP+1); SP <- SP+2"},
+	0xe2: {Mnemonic: "JPO", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if PO, PC <- adr"},
+	0xe3: {Mnemonic: "XTHL", Size: 1, Cycles: 18, CyclesTaken: 18, Function: "L <-> (SP); H <-> (SP+1)"},
+	0xe4: {Mnemonic: "CPO", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if PO, CALL adr"},
+	0xe5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "(SP-2) <- L; (SP-1) <- H; SP <- SP-2"},
+	0xe6: {Mnemonic: "ANI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & data"},
+	0xe7: {Mnemonic: "RST", Operands: []Operand{{Restart, "4"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $20"},
+	0xe8: {Mnemonic: "RPE", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if PE, RET"},
+	0xe9: {Mnemonic: "PCHL", Size: 1, Cycles: 5, CyclesTaken: 5, Function: "PC.hi <- H; PC.lo <- L"},
+	0xea: {Mnemonic: "JPE", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if PE, PC <- adr"},
+	0xeb: {Mnemonic: "XCHG", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <-> D; L <-> E"},
+	0xec: {Mnemonic: "CPE", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if PE, CALL adr"},
+	0xed: {Mnemonic: "CALL", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 17, CyclesTaken: 17, Function: "undocumented alias of CALL", Undocumented: true},
+	0xee: {Mnemonic: "XRI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ data"},
+	0xef: {Mnemonic: "RST", Operands: []Operand{{Restart, "5"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $28"},
+	0xf0: {Mnemonic: "RP", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if P, RET"},
+	0xf1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "PSW"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "flags <- (SP); A <- (SP+1); SP <- SP+2"},
+	0xf2: {Mnemonic: "JP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if P, PC <- adr"},
+	0xf3: {Mnemonic: "DI", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "disable interrupts"},
+	0xf4: {Mnemonic: "CP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if P, CALL adr"},
+	0xf5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "PSW"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "(SP-2) <- flags; (SP-1) <- A; SP <- SP-2"},
+	0xf6: {Mnemonic: "ORI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xf7: {Mnemonic: "RST", Operands: []Operand{{Restart, "6"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $30"},
+	0xf8: {Mnemonic: "RM", Size: 1, Cycles: 5, CyclesTaken: 11, Function: "if M, RET"},
+	0xf9: {Mnemonic: "SPHL", Size: 1, Cycles: 5, CyclesTaken: 5, Function: "SP <- HL"},
+	0xfa: {Mnemonic: "JM", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "if M, PC <- adr"},
+	0xfb: {Mnemonic: "EI", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "enable interrupts"},
+	0xfc: {Mnemonic: "CM", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 11, CyclesTaken: 17, Function: "if M, CALL adr"},
+	0xfd: {Mnemonic: "CALL", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 17, CyclesTaken: 17, Function: "undocumented alias of CALL", Undocumented: true},
+	0xfe: {Mnemonic: "CPI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - data"},
+	0xff: {Mnemonic: "RST", Operands: []Operand{{Restart, "7"}}, Size: 1, Cycles: 11, CyclesTaken: 11, Function: "CALL $38"},
+}
+
+// Intel8085 lists all opcodes of 8085 indexed with their value
+var Intel8085 = [256]Opcode{
+	0x00: {Mnemonic: "NOP", Size: 1, Cycles: 4, CyclesTaken: 4},
+	0x01: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "B"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "B <- byte 3, C <- byte 2"},
+	0x02: {Mnemonic: "STAX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(BC) <- A"},
+	0x03: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "BC <- BC+1"},
+	0x04: {Mnemonic: "INR", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "B <- B+1"},
+	0x05: {Mnemonic: "DCR", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "B <- B-1"},
+	0x06: {Mnemonic: "MVI", Operands: []Operand{{Register, "B"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "B <- byte 2"},
+	0x07: {Mnemonic: "RLC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A << 1; bit 0 = prev bit 7; CY = prev bit 7"},
+	0x08: {Mnemonic: "DSUB", Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "HL <- HL - BC", Undocumented: true},
+	0x09: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + BC"},
+	0x0a: {Mnemonic: "LDAX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "A <- (BC)"},
+	0x0b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "BC <- BC-1"},
+	0x0c: {Mnemonic: "INR", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "C <- C+1"},
+	0x0d: {Mnemonic: "DCR", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "C <- C-1"},
+	0x0e: {Mnemonic: "MVI", Operands: []Operand{{Register, "C"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "C <- byte 2"},
+	0x0f: {Mnemonic: "RRC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A >> 1; bit 7 = prev bit 0; CY = prev bit 0"},
+	0x10: {Mnemonic: "ARHL", Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagCY, Function: "HL = HL >> 1; bit 15 = prev bit 15; CY = prev bit 0", Undocumented: true},
+	0x11: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "D"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "D <- byte 3, E <- byte 2"},
+	0x12: {Mnemonic: "STAX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(DE) <- A"},
+	0x13: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "DE <- DE + 1"},
+	0x14: {Mnemonic: "INR", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "D <- D+1"},
+	0x15: {Mnemonic: "DCR", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "D <- D-1"},
+	0x16: {Mnemonic: "MVI", Operands: []Operand{{Register, "D"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "D <- byte 2"},
+	0x17: {Mnemonic: "RAL", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A << 1; bit 0 = prev CY; CY = prev bit 7"},
+	0x18: {Mnemonic: "RDEL", Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "DE = DE << 1; bit 0 = prev CY; CY = prev bit 15", Undocumented: true},
+	0x19: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + DE"},
+	0x1a: {Mnemonic: "LDAX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "A <- (DE)"},
+	0x1b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "DE <- DE-1"},
+	0x1c: {Mnemonic: "INR", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "E <- E+1"},
+	0x1d: {Mnemonic: "DCR", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "E <- E-1"},
+	0x1e: {Mnemonic: "MVI", Operands: []Operand{{Register, "E"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "E <- byte 2"},
+	0x1f: {Mnemonic: "RAR", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "A = A >> 1; bit 7 = prev CY; CY = prev bit 0"},
+	0x20: {Mnemonic: "RIM", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- interrupt masks, pending interrupts and SID"},
+	0x21: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "H"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "H <- byte 3, L <- byte 2"},
+	0x22: {Mnemonic: "SHLD", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 16, CyclesTaken: 16, Function: "(adr) <- L; (adr+1) <- H"},
+	0x23: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "HL <- HL + 1"},
+	0x24: {Mnemonic: "INR", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "H <- H+1"},
+	0x25: {Mnemonic: "DCR", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "H <- H-1"},
+	0x26: {Mnemonic: "MVI", Operands: []Operand{{Register, "H"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "H <- byte 2"},
+	0x27: {Mnemonic: "DAA", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "decimal adjust A"},
+	0x28: {Mnemonic: "LDHI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "DE <- HL + byte 2", Undocumented: true},
+	0x29: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + HL"},
+	0x2a: {Mnemonic: "LHLD", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 16, CyclesTaken: 16, Function: "L <- (adr); H <- (adr+1)"},
+	0x2b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "HL <- HL-1"},
+	0x2c: {Mnemonic: "INR", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "L <- L+1"},
+	0x2d: {Mnemonic: "DCR", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "L <- L-1"},
+	0x2e: {Mnemonic: "MVI", Operands: []Operand{{Register, "L"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "L <- byte 2"},
+	0x2f: {Mnemonic: "CMA", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- !A"},
+	0x30: {Mnemonic: "SIM", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "interrupt masks and SOD <- A"},
+	0x31: {Mnemonic: "LXI", Operands: []Operand{{RegisterPair, "SP"}, {Data16, "D16"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "SP.hi <- byte 3, SP.lo <- byte 2"},
+	0x32: {Mnemonic: "STA", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 13, CyclesTaken: 13, Function: "(adr) <- A"},
+	0x33: {Mnemonic: "INX", Operands: []Operand{{RegisterPair, "SP"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "SP <- SP+1"},
+	0x34: {Mnemonic: "INR", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "(HL) <- (HL)+1"},
+	0x35: {Mnemonic: "DCR", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "(HL) <- (HL)-1"},
+	0x36: {Mnemonic: "MVI", Operands: []Operand{{Register, "M"}, {Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "(HL) <- byte 2"},
+	0x37: {Mnemonic: "STC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "CY = 1"},
+	0x38: {Mnemonic: "LDSI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "DE <- SP + byte 2", Undocumented: true},
+	0x39: {Mnemonic: "DAD", Operands: []Operand{{RegisterPair, "SP"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagCY, Function: "HL <- HL + SP"},
+	0x3a: {Mnemonic: "LDA", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 13, CyclesTaken: 13, Function: "A <- (adr)"},
+	0x3b: {Mnemonic: "DCX", Operands: []Operand{{RegisterPair, "SP"}}, Size: 1, Cycles: 6, CyclesTaken: 6, Function: "SP <- SP-1"},
+	0x3c: {Mnemonic: "INR", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "A <- A+1"},
+	0x3d: {Mnemonic: "DCR", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagAC, Function: "A <- A-1"},
+	0x3e: {Mnemonic: "MVI", Operands: []Operand{{Register, "A"}, {Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Function: "A <- byte 2"},
+	0x3f: {Mnemonic: "CMC", Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagCY, Function: "CY = !CY"},
+	0x40: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- B"},
+	0x41: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- C"},
+	0x42: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- D"},
+	0x43: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- E"},
+	0x44: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- H"},
+	0x45: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- L"},
+	0x46: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "B <- (HL)"},
+	0x47: {Mnemonic: "MOV", Operands: []Operand{{Register, "B"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "B <- A"},
+	0x48: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- B"},
+	0x49: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- C"},
+	0x4a: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- D"},
+	0x4b: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- E"},
+	0x4c: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- H"},
+	0x4d: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- L"},
+	0x4e: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "C <- (HL)"},
+	0x4f: {Mnemonic: "MOV", Operands: []Operand{{Register, "C"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "C <- A"},
+	0x50: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- B"},
+	0x51: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- C"},
+	0x52: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- D"},
+	0x53: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- E"},
+	0x54: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- H"},
+	0x55: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- L"},
+	0x56: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "D <- (HL)"},
+	0x57: {Mnemonic: "MOV", Operands: []Operand{{Register, "D"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "D <- A"},
+	0x58: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- B"},
+	0x59: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- C"},
+	0x5a: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- D"},
+	0x5b: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- E"},
+	0x5c: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- H"},
+	0x5d: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- L"},
+	0x5e: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "E <- (HL)"},
+	0x5f: {Mnemonic: "MOV", Operands: []Operand{{Register, "E"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "E <- A"},
+	0x60: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- B"},
+	0x61: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- C"},
+	0x62: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- D"},
+	0x63: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- E"},
+	0x64: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- H"},
+	0x65: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- L"},
+	0x66: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "H <- (HL)"},
+	0x67: {Mnemonic: "MOV", Operands: []Operand{{Register, "H"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <- A"},
+	0x68: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- B"},
+	0x69: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- C"},
+	0x6a: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- D"},
+	0x6b: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- E"},
+	0x6c: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- H"},
+	0x6d: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- L"},
+	0x6e: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "L <- (HL)"},
+	0x6f: {Mnemonic: "MOV", Operands: []Operand{{Register, "L"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "L <- A"},
+	0x70: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "B"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- B"},
+	0x71: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "C"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- C"},
+	0x72: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "D"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- D"},
+	0x73: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "E"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- E"},
+	0x74: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "H"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- H"},
+	0x75: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "L"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- L"},
+	0x76: {Mnemonic: "HLT", Size: 1, Cycles: 5, CyclesTaken: 5, Function: "halt until interrupt"},
+	0x77: {Mnemonic: "MOV", Operands: []Operand{{Register, "M"}, {Register, "A"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "(HL) <- A"},
+	0x78: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- B"},
+	0x79: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- C"},
+	0x7a: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- D"},
+	0x7b: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- E"},
+	0x7c: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- H"},
+	0x7d: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- L"},
+	0x7e: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Function: "A <- (HL)"},
+	0x7f: {Mnemonic: "MOV", Operands: []Operand{{Register, "A"}, {Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Function: "A <- A"},
+	0x80: {Mnemonic: "ADD", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + B"},
+	0x81: {Mnemonic: "ADD", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + C"},
+	0x82: {Mnemonic: "ADD", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + D"},
+	0x83: {Mnemonic: "ADD", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + E"},
+	0x84: {Mnemonic: "ADD", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + H"},
+	0x85: {Mnemonic: "ADD", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + L"},
+	0x86: {Mnemonic: "ADD", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + (HL)"},
+	0x87: {Mnemonic: "ADD", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + A"},
+	0x88: {Mnemonic: "ADC", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + B + CY"},
+	0x89: {Mnemonic: "ADC", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + C + CY"},
+	0x8a: {Mnemonic: "ADC", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + D + CY"},
+	0x8b: {Mnemonic: "ADC", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + E + CY"},
+	0x8c: {Mnemonic: "ADC", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + H + CY"},
+	0x8d: {Mnemonic: "ADC", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + L + CY"},
+	0x8e: {Mnemonic: "ADC", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + (HL) + CY"},
+	0x8f: {Mnemonic: "ADC", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + A + CY"},
+	0x90: {Mnemonic: "SUB", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - B"},
+	0x91: {Mnemonic: "SUB", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - C"},
+	0x92: {Mnemonic: "SUB", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - D"},
+	0x93: {Mnemonic: "SUB", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - E"},
+	0x94: {Mnemonic: "SUB", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - H"},
+	0x95: {Mnemonic: "SUB", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - L"},
+	0x96: {Mnemonic: "SUB", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - (HL)"},
+	0x97: {Mnemonic: "SUB", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - A"},
+	0x98: {Mnemonic: "SBB", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - B - CY"},
+	0x99: {Mnemonic: "SBB", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - C - CY"},
+	0x9a: {Mnemonic: "SBB", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - D - CY"},
+	0x9b: {Mnemonic: "SBB", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - E - CY"},
+	0x9c: {Mnemonic: "SBB", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - H - CY"},
+	0x9d: {Mnemonic: "SBB", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - L - CY"},
+	0x9e: {Mnemonic: "SBB", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - (HL) - CY"},
+	0x9f: {Mnemonic: "SBB", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - A - CY"},
+	0xa0: {Mnemonic: "ANA", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & B"},
+	0xa1: {Mnemonic: "ANA", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & C"},
+	0xa2: {Mnemonic: "ANA", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & D"},
+	0xa3: {Mnemonic: "ANA", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & E"},
+	0xa4: {Mnemonic: "ANA", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & H"},
+	0xa5: {Mnemonic: "ANA", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & L"},
+	0xa6: {Mnemonic: "ANA", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & (HL)"},
+	0xa7: {Mnemonic: "ANA", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & A"},
+	0xa8: {Mnemonic: "XRA", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ B"},
+	0xa9: {Mnemonic: "XRA", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ C"},
+	0xaa: {Mnemonic: "XRA", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ D"},
+	0xab: {Mnemonic: "XRA", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ E"},
+	0xac: {Mnemonic: "XRA", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ H"},
+	0xad: {Mnemonic: "XRA", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ L"},
+	0xae: {Mnemonic: "XRA", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ (HL)"},
+	0xaf: {Mnemonic: "XRA", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ A"},
+	0xb0: {Mnemonic: "ORA", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb1: {Mnemonic: "ORA", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb2: {Mnemonic: "ORA", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb3: {Mnemonic: "ORA", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb4: {Mnemonic: "ORA", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb5: {Mnemonic: "ORA", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb6: {Mnemonic: "ORA", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb7: {Mnemonic: "ORA", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xb8: {Mnemonic: "CMP", Operands: []Operand{{Register, "B"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - B"},
+	0xb9: {Mnemonic: "CMP", Operands: []Operand{{Register, "C"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - C"},
+	0xba: {Mnemonic: "CMP", Operands: []Operand{{Register, "D"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - D"},
+	0xbb: {Mnemonic: "CMP", Operands: []Operand{{Register, "E"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - E"},
+	0xbc: {Mnemonic: "CMP", Operands: []Operand{{Register, "H"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - H"},
+	0xbd: {Mnemonic: "CMP", Operands: []Operand{{Register, "L"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - L"},
+	0xbe: {Mnemonic: "CMP", Operands: []Operand{{Register, "M"}}, Size: 1, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - (HL)"},
+	0xbf: {Mnemonic: "CMP", Operands: []Operand{{Register, "A"}}, Size: 1, Cycles: 4, CyclesTaken: 4, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - A"},
+	0xc0: {Mnemonic: "RNZ", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if NZ, RET"},
+	0xc1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Function: "C <- (SP); B <- (SP+1); SP <- SP+2"},
+	0xc2: {Mnemonic: "JNZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if NZ, PC <- adr"},
+	0xc3: {Mnemonic: "JMP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 10, CyclesTaken: 10, Function: "PC <- adr"},
+	0xc4: {Mnemonic: "CNZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if NZ, CALL adr"},
+	0xc5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "B"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "(SP-2) <- C; (SP-1) <- B; SP <- SP-2"},
+	0xc6: {Mnemonic: "ADI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + byte"},
+	0xc7: {Mnemonic: "RST", Operands: []Operand{{Restart, "0"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $0"},
+	0xc8: {Mnemonic: "RZ", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if Z, RET"},
+	0xc9: {Mnemonic: "RET", Size: 1, Cycles: 10, CyclesTaken: 10, Function: "PC.lo <- (SP); PC.hi <- (SP+1); SP <- SP+2"},
+	0xca: {Mnemonic: "JZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if Z, PC <- adr"},
+	0xcb: {Mnemonic: "RSTV", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if V, CALL 0x40", Undocumented: true},
+	0xcc: {Mnemonic: "CZ", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if Z, CALL adr"},
+	0xcd: {Mnemonic: "CALL", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 18, CyclesTaken: 18, Function: "(SP-1) <- PC.hi; (SP-2) <- PC.lo; SP <- SP-2; PC <- adr"},
+	0xce: {Mnemonic: "ACI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A + data + CY"},
+	0xcf: {Mnemonic: "RST", Operands: []Operand{{Restart, "1"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $8"},
+	0xd0: {Mnemonic: "RNC", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if NCY, RET"},
+	0xd1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Function: "E <- (SP); D <- (SP+1); SP <- SP+2"},
+	0xd2: {Mnemonic: "JNC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if NCY, PC <- adr"},
+	0xd3: {Mnemonic: "OUT", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "port <- A"},
+	0xd4: {Mnemonic: "CNC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if NCY, CALL adr"},
+	0xd5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "D"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "(SP-2) <- E; (SP-1) <- D; SP <- SP-2"},
+	0xd6: {Mnemonic: "SUI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - data"},
+	0xd7: {Mnemonic: "RST", Operands: []Operand{{Restart, "2"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $10"},
+	0xd8: {Mnemonic: "RC", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if CY, RET"},
+	0xd9: {Mnemonic: "SHLX", Size: 1, Cycles: 10, CyclesTaken: 10, Function: "(DE) <- L; (DE+1) <- H", Undocumented: true},
+	0xda: {Mnemonic: "JC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if CY, PC <- adr"},
+	0xdb: {Mnemonic: "IN", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 10, CyclesTaken: 10, Function: "A <- port"},
+	0xdc: {Mnemonic: "CC", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if CY, CALL adr"},
+	0xdd: {Mnemonic: "JNK", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if not K, PC <- adr", Undocumented: true},
+	0xde: {Mnemonic: "SBI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A - data - CY"},
+	0xdf: {Mnemonic: "RST", Operands: []Operand{{Restart, "3"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $18"},
+	0xe0: {Mnemonic: "RPO", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if PO, RET"},
+	0xe1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Function: "L <- (SP); H <- (SP+1); SP <- SP+2"},
+	0xe2: {Mnemonic: "JPO", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if PO, PC <- adr"},
+	0xe3: {Mnemonic: "XTHL", Size: 1, Cycles: 16, CyclesTaken: 16, Function: "L <-> (SP); H <-> (SP+1)"},
+	0xe4: {Mnemonic: "CPO", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if PO, CALL adr"},
+	0xe5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "H"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "(SP-2) <- L; (SP-1) <- H; SP <- SP-2"},
+	0xe6: {Mnemonic: "ANI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A & data"},
+	0xe7: {Mnemonic: "RST", Operands: []Operand{{Restart, "4"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $20"},
+	0xe8: {Mnemonic: "RPE", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if PE, RET"},
+	0xe9: {Mnemonic: "PCHL", Size: 1, Cycles: 6, CyclesTaken: 6, Function: "PC.hi <- H; PC.lo <- L"},
+	0xea: {Mnemonic: "JPE", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if PE, PC <- adr"},
+	0xeb: {Mnemonic: "XCHG", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "H <-> D; L <-> E"},
+	0xec: {Mnemonic: "CPE", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if PE, CALL adr"},
+	0xed: {Mnemonic: "LHLX", Size: 1, Cycles: 10, CyclesTaken: 10, Function: "L <- (DE); H <- (DE+1)", Undocumented: true},
+	0xee: {Mnemonic: "XRI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A ^ data"},
+	0xef: {Mnemonic: "RST", Operands: []Operand{{Restart, "5"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $28"},
+	0xf0: {Mnemonic: "RP", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if P, RET"},
+	0xf1: {Mnemonic: "POP", Operands: []Operand{{RegisterPair, "PSW"}}, Size: 1, Cycles: 10, CyclesTaken: 10, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "flags <- (SP); A <- (SP+1); SP <- SP+2"},
+	0xf2: {Mnemonic: "JP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if P, PC <- adr"},
+	0xf3: {Mnemonic: "DI", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "disable interrupts"},
+	0xf4: {Mnemonic: "CP", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if P, CALL adr"},
+	0xf5: {Mnemonic: "PUSH", Operands: []Operand{{RegisterPair, "PSW"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "(SP-2) <- flags; (SP-1) <- A; SP <- SP-2"},
+	0xf6: {Mnemonic: "ORI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A <- A"},
+	0xf7: {Mnemonic: "RST", Operands: []Operand{{Restart, "6"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $30"},
+	0xf8: {Mnemonic: "RM", Size: 1, Cycles: 6, CyclesTaken: 12, Function: "if M, RET"},
+	0xf9: {Mnemonic: "SPHL", Size: 1, Cycles: 6, CyclesTaken: 6, Function: "SP <- HL"},
+	0xfa: {Mnemonic: "JM", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if M, PC <- adr"},
+	0xfb: {Mnemonic: "EI", Size: 1, Cycles: 4, CyclesTaken: 4, Function: "enable interrupts"},
+	0xfc: {Mnemonic: "CM", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 9, CyclesTaken: 18, Function: "if M, CALL adr"},
+	0xfd: {Mnemonic: "JK", Operands: []Operand{{Address, "adr"}}, Size: 3, Cycles: 7, CyclesTaken: 10, Function: "if K, PC <- adr", Undocumented: true},
+	0xfe: {Mnemonic: "CPI", Operands: []Operand{{Data8, "D8"}}, Size: 2, Cycles: 7, CyclesTaken: 7, Flags: FlagZ | FlagS | FlagP | FlagCY | FlagAC, Function: "A - data"},
+	0xff: {Mnemonic: "RST", Operands: []Operand{{Restart, "7"}}, Size: 1, Cycles: 12, CyclesTaken: 12, Function: "CALL $38"},
+}
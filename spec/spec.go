@@ -0,0 +1,96 @@
+// Package spec describes 8080 and 8085 instruction sets; i8080.txt is the only place instructions
+// are listed, Go tables are generated from it
+package spec
+
+import (
+	"strings"
+)
+
+//go:generate go run gen.go
+
+// OperandKind tells what an operand written in the mnemonic stands for
+type OperandKind int
+
+const (
+	// Register is one of B, C, D, E, H, L, A or M for memory cell addressed by hl
+	Register OperandKind = iota
+	// RegisterPair is one of B, D, H, SP or PSW
+	RegisterPair
+	// Data8 is 8bit immediate value following the opcode
+	Data8
+	// Data16 is 16bit immediate value following the opcode
+	Data16
+	// Address is 16bit address following the opcode
+	Address
+	// Restart is number of restart routine encoded in the opcode
+	Restart
+)
+
+// Operand is a single operand of an instruction as written in the mnemonic
+type Operand struct {
+	Kind OperandKind
+	Text string
+}
+
+// Size returns number of bytes operand takes after the opcode
+func (o Operand) Size() int {
+	switch o.Kind {
+	case Data8:
+		return 1
+	case Data16, Address:
+		return 2
+	}
+	return 0
+}
+
+// Flags is a set of condition flags
+type Flags uint8
+
+const (
+	FlagZ  Flags = 1 << iota // zero
+	FlagS                    // sign
+	FlagP                    // parity
+	FlagCY                   // carry
+	FlagAC                   // auxiliary carry
+)
+
+var flagNames = []string{"Z", "S", "P", "CY", "AC"}
+
+func (f Flags) String() string {
+	var names []string
+	for i, name := range flagNames {
+		if f&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+	return strings.Join(names, ", ")
+}
+
+// Opcode describes single opcode of the instruction set
+type Opcode struct {
+	Mnemonic     string
+	Operands     []Operand
+	Size         int // in bytes, including the opcode
+	Cycles       int // for conditional instructions when condition isn't met
+	CyclesTaken  int // for conditional instructions when condition is met, equal to Cycles otherwise
+	Flags        Flags
+	Function     string
+	Undocumented bool // missing from documentation; on 8080 executed as alias of the mnemonic
+}
+
+// Name returns mnemonic with its operands, undocumented opcodes are marked with *
+func (o Opcode) Name() string {
+	name := o.Mnemonic
+	if o.Undocumented {
+		name = "*" + name
+	}
+	if len(o.Operands) == 0 {
+		return name
+	}
+
+	operands := make([]string, len(o.Operands))
+	for i, operand := range o.Operands {
+		operands[i] = operand.Text
+	}
+	return name + " " + strings.Join(operands, ",")
+}
@@ -0,0 +1,40 @@
+package spec
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestName(t *testing.T) {
+	assert.Equal(t, "NOP", Intel8080[0x00].Name())
+	assert.Equal(t, "LXI B,D16", Intel8080[0x01].Name(), "lists operands")
+	assert.Equal(t, "*CALL adr", Intel8080[0xdd].Name(), "marks undocumented opcodes")
+	assert.Equal(t, "RIM", Intel8085[0x20].Name(), "describes 8085 extensions")
+	assert.Equal(t, "*RSTV", Intel8085[0xcb].Name(), "describes 8085 undocumented instructions")
+	assert.Equal(t, "*LHLX", Intel8085[0xed].Name(), "does not inherit 8080 aliases")
+}
+
+func TestFlags(t *testing.T) {
+	assert.Equal(t, "Z, S, P, CY, AC", Intel8080[0x80].Flags.String())
+	assert.Equal(t, "CY", Intel8080[0x09].Flags.String())
+	assert.Equal(t, "", Intel8080[0x00].Flags.String())
+}
+
+func TestSize(t *testing.T) {
+	assert.Equal(t, 3, Intel8080[0xcb].Size, "takes operands of 8080 aliases")
+	assert.Equal(t, 1, Intel8085[0xcb].Size, "takes operands of 8085 instructions")
+	assert.Equal(t, 2, Intel8085[0x28].Size, "takes operands of 8085 instructions")
+
+	for set, opcodes := range map[string]*[256]Opcode{"8080": &Intel8080, "8085": &Intel8085} {
+		t.Run(set, func(t *testing.T) {
+			for _, op := range opcodes {
+				size := 1
+				for _, operand := range op.Operands {
+					size += operand.Size()
+				}
+				assert.Equal(t, size, op.Size, op.Name())
+			}
+		})
+	}
+}
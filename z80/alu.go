@@ -0,0 +1,283 @@
+package z80
+
+import (
+	"math/bits"
+)
+
+// szpTable holds sign, zero, parity and undocumented flags of every result
+var szpTable [256]uint8
+
+func init() {
+	for i := range szpTable {
+		val := uint8(i)
+		flags := val & (flagS | flagsXY)
+		if val == 0 {
+			flags |= flagZ
+		}
+		if bits.OnesCount8(val)%2 == 0 {
+			flags |= flagPV
+		}
+		szpTable[i] = flags
+	}
+}
+
+// sz returns sign, zero and undocumented flags of provided result
+func sz(val uint8) uint8 {
+	return szpTable[val] &^ flagPV
+}
+
+// alu performs one of eight accumulator operations encoded in opcodes: ADD, ADC, SUB, SBC, AND,
+// XOR, OR and CP
+func (s *CPU) alu(operation uint8, val uint8) {
+	switch operation {
+	case 0:
+		s.add8(val, 0)
+	case 1:
+		s.add8(val, s.f&flagC)
+	case 2:
+		s.a = s.sub8(val, 0)
+	case 3:
+		s.a = s.sub8(val, s.f&flagC)
+	case 4:
+		s.a &= val
+		s.f = szpTable[s.a] | flagH
+	case 5:
+		s.a ^= val
+		s.f = szpTable[s.a]
+	case 6:
+		s.a |= val
+		s.f = szpTable[s.a]
+	case 7:
+		s.sub8(val, 0)
+		s.f = s.f&^flagsXY | val&flagsXY // CP takes undocumented flags from the operand
+	}
+}
+
+// add8 adds provided value and carry to accumulator
+func (s *CPU) add8(val, carry uint8) {
+	sum := uint16(s.a) + uint16(val) + uint16(carry)
+	res := uint8(sum)
+
+	flags := sz(res) | uint8(sum>>8)&flagC | (s.a^val^res)&flagH
+	if (s.a^val)&0x80 == 0 && (s.a^res)&0x80 != 0 {
+		flags |= flagPV
+	}
+
+	s.a, s.f = res, flags
+}
+
+// sub8 subtracts provided value and borrow from accumulator, sets flags and returns the result
+// without storing it
+func (s *CPU) sub8(val, borrow uint8) uint8 {
+	diff := uint16(s.a) - uint16(val) - uint16(borrow)
+	res := uint8(diff)
+
+	flags := sz(res) | flagN | (s.a^val^res)&flagH
+	if diff > 0xff {
+		flags |= flagC
+	}
+	if (s.a^val)&0x80 != 0 && (s.a^res)&0x80 != 0 {
+		flags |= flagPV
+	}
+
+	s.f = flags
+	return res
+}
+
+// inc8 returns incremented value; carry is not affected
+func (s *CPU) inc8(val uint8) uint8 {
+	res := val + 1
+
+	flags := s.f&flagC | sz(res)
+	if val&0x0f == 0x0f {
+		flags |= flagH
+	}
+	if val == 0x7f {
+		flags |= flagPV
+	}
+
+	s.f = flags
+	return res
+}
+
+// dec8 returns decremented value; carry is not affected
+func (s *CPU) dec8(val uint8) uint8 {
+	res := val - 1
+
+	flags := s.f&flagC | sz(res) | flagN
+	if val&0x0f == 0 {
+		flags |= flagH
+	}
+	if val == 0x80 {
+		flags |= flagPV
+	}
+
+	s.f = flags
+	return res
+}
+
+// add16 returns sum of provided values; sign, zero and parity flags are not affected
+func (s *CPU) add16(x, y uint16) uint16 {
+	sum := uint32(x) + uint32(y)
+	res := uint16(sum)
+
+	s.f = s.f&(flagS|flagZ|flagPV) | uint8(sum>>16)&flagC | uint8((x^y^res)>>8)&flagH | uint8(res>>8)&flagsXY
+	return res
+}
+
+// adc16 adds provided value and carry to hl
+func (s *CPU) adc16(val uint16) {
+	hl := s.hl()
+	sum := uint32(hl) + uint32(val) + uint32(s.f&flagC)
+	res := uint16(sum)
+
+	flags := uint8(res>>8)&(flagS|flagsXY) | uint8(sum>>16)&flagC | uint8((hl^val^res)>>8)&flagH
+	if res == 0 {
+		flags |= flagZ
+	}
+	if (hl^val)&0x8000 == 0 && (hl^res)&0x8000 != 0 {
+		flags |= flagPV
+	}
+
+	s.setHL(res)
+	s.f = flags
+}
+
+// sbc16 subtracts provided value and borrow from hl
+func (s *CPU) sbc16(val uint16) {
+	hl := s.hl()
+	diff := uint32(hl) - uint32(val) - uint32(s.f&flagC)
+	res := uint16(diff)
+
+	flags := uint8(res>>8)&(flagS|flagsXY) | flagN | uint8((hl^val^res)>>8)&flagH
+	if diff > 0xffff {
+		flags |= flagC
+	}
+	if res == 0 {
+		flags |= flagZ
+	}
+	if (hl^val)&0x8000 != 0 && (hl^res)&0x8000 != 0 {
+		flags |= flagPV
+	}
+
+	s.setHL(res)
+	s.f = flags
+}
+
+// rotateA performs one of RLCA, RRCA, RLA and RRA; they keep sign, zero and parity flags
+func (s *CPU) rotateA(operation uint8) {
+	var carry uint8
+	switch operation {
+	case 0: // RLCA
+		carry = s.a >> 7
+		s.a = s.a<<1 | carry
+	case 1: // RRCA
+		carry = s.a & 1
+		s.a = s.a>>1 | carry<<7
+	case 2: // RLA
+		carry = s.a >> 7
+		s.a = s.a<<1 | s.f&flagC
+	case 3: // RRA
+		carry = s.a & 1
+		s.a = s.a>>1 | (s.f&flagC)<<7
+	}
+
+	s.f = s.f&(flagS|flagZ|flagPV) | s.a&flagsXY | carry
+}
+
+// rotate performs one of CB prefixed rotations and shifts: RLC, RRC, RL, RR, SLA, SRA, SLL and SRL
+func (s *CPU) rotate(operation uint8, val uint8) uint8 {
+	var res, carry uint8
+	switch operation {
+	case 0: // RLC
+		carry = val >> 7
+		res = val<<1 | carry
+	case 1: // RRC
+		carry = val & 1
+		res = val>>1 | carry<<7
+	case 2: // RL
+		carry = val >> 7
+		res = val<<1 | s.f&flagC
+	case 3: // RR
+		carry = val & 1
+		res = val>>1 | (s.f&flagC)<<7
+	case 4: // SLA
+		carry = val >> 7
+		res = val << 1
+	case 5: // SRA
+		carry = val & 1
+		res = val>>1 | val&0x80
+	case 6: // SLL, undocumented
+		carry = val >> 7
+		res = val<<1 | 1
+	case 7: // SRL
+		carry = val & 1
+		res = val >> 1
+	}
+
+	s.f = szpTable[res] | carry
+	return res
+}
+
+// bit tests n-th bit of provided value
+func (s *CPU) bit(n uint8, val uint8) {
+	flags := s.f&flagC | flagH | val&flagsXY
+	if val&(1<<n) == 0 {
+		flags |= flagZ | flagPV
+	} else if n == 7 {
+		flags |= flagS
+	}
+
+	s.f = flags
+}
+
+// daa adjusts accumulator to packed BCD after addition or subtraction
+func (s *CPU) daa() {
+	var diff uint8
+	flags := s.f & flagN
+
+	if s.f&flagH != 0 || s.a&0x0f > 9 {
+		diff = 0x06
+	}
+	if s.f&flagC != 0 || s.a > 0x99 {
+		diff |= 0x60
+		flags |= flagC
+	}
+
+	if s.f&flagN != 0 {
+		if s.f&flagH != 0 && s.a&0x0f < 6 {
+			flags |= flagH
+		}
+		s.a -= diff
+	} else {
+		if s.a&0x0f > 9 {
+			flags |= flagH
+		}
+		s.a += diff
+	}
+
+	s.f = flags | szpTable[s.a]
+}
+
+// cpl complements accumulator
+func (s *CPU) cpl() {
+	s.a = ^s.a
+	s.f = s.f&(flagS|flagZ|flagPV|flagC) | flagH | flagN | s.a&flagsXY
+}
+
+// scf sets carry flag
+func (s *CPU) scf() {
+	s.f = s.f&(flagS|flagZ|flagPV) | flagC | s.a&flagsXY
+}
+
+// ccf complements carry flag; half carry gets its previous value
+func (s *CPU) ccf() {
+	flags := s.f&(flagS|flagZ|flagPV) | s.a&flagsXY
+	if s.f&flagC != 0 {
+		flags |= flagH
+	} else {
+		flags |= flagC
+	}
+
+	s.f = flags
+}
@@ -0,0 +1,493 @@
+package z80
+
+// Step serves pending interrupt if CPU accepts it, otherwise it executes single instruction.
+// Halted CPU executes NOPs until interrupted. It returns number of cycles spent.
+func (s *CPU) Step() (int, error) {
+	if s.nmiRequest {
+		return s.serveNMI(), nil
+	}
+	if s.eiDelay {
+		s.eiDelay = false
+	} else if s.interruptPending() {
+		return s.serveInterrupt(), nil
+	}
+
+	if s.halted {
+		s.refresh()
+		s.cycles += haltedCycles
+		return haltedCycles, nil
+	}
+
+	start := s.cycles
+	s.execute(s.fetchOpcode())
+	return int(s.cycles - start), nil
+}
+
+// execute runs instruction starting with provided opcode, following any prefixes
+func (s *CPU) execute(opCode uint8) {
+	s.index = useHL
+	s.dispLoaded = false
+
+	for opCode == 0xdd || opCode == 0xfd {
+		s.index = useIX
+		if opCode == 0xfd {
+			s.index = useIY
+		}
+		s.cycles += prefixCycles
+		opCode = s.fetchOpcode()
+	}
+
+	switch {
+	case opCode == 0xcb && s.index != useHL:
+		s.executeIndexedCB()
+	case opCode == 0xcb:
+		s.executeCB(s.fetchOpcode())
+	case opCode == 0xed:
+		s.index = useHL // prefix followed by ED works as NOP
+		s.executeED(s.fetchOpcode())
+	default:
+		s.cycles += uint64(cycles[opCode])
+		s.executeMain(opCode)
+	}
+}
+
+// executeMain runs unprefixed opcode; it's decoded from x, y, z fields of its bits (xxyyyzzz), with
+// y further split into p and q (ppq)
+func (s *CPU) executeMain(opCode uint8) {
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+	p, q := y>>1, y&1
+
+	switch x {
+	case 0:
+		switch z {
+		case 0:
+			switch y {
+			case 0: // NOP
+			case 1: // EX AF,AF'
+				af := s.AF()
+				s.SetAF(s.af2)
+				s.af2 = af
+			case 2: // DJNZ e
+				offset := s.fetch()
+				s.b--
+				s.jr(s.b != 0, offset)
+			case 3: // JR e
+				offset := s.fetch()
+				s.pc += uint16(int8(offset))
+			default: // JR cc,e
+				s.jr(s.condition(y-4), s.fetch())
+			}
+		case 1:
+			if q == 0 { // LD rp,nn
+				s.setRP(p, s.fetch16())
+			} else { // ADD HL,rp
+				s.setHL(s.add16(s.hl(), s.rp(p)))
+			}
+		case 2:
+			switch y {
+			case 0: // LD (BC),A
+				s.mem.Write(s.BC(), s.a)
+			case 1: // LD A,(BC)
+				s.a = s.mem.Read(s.BC())
+			case 2: // LD (DE),A
+				s.mem.Write(s.DE(), s.a)
+			case 3: // LD A,(DE)
+				s.a = s.mem.Read(s.DE())
+			case 4: // LD (nn),HL
+				s.write16(s.fetch16(), s.hl())
+			case 5: // LD HL,(nn)
+				s.setHL(s.read16(s.fetch16()))
+			case 6: // LD (nn),A
+				s.mem.Write(s.fetch16(), s.a)
+			case 7: // LD A,(nn)
+				s.a = s.mem.Read(s.fetch16())
+			}
+		case 3:
+			if q == 0 { // INC rp
+				s.setRP(p, s.rp(p)+1)
+			} else { // DEC rp
+				s.setRP(p, s.rp(p)-1)
+			}
+		case 4: // INC r
+			s.setReg(y, s.inc8(s.reg(y)))
+		case 5: // DEC r
+			s.setReg(y, s.dec8(s.reg(y)))
+		case 6: // LD r,n
+			if y == m && s.index != useHL {
+				s.operand()
+				s.cycles -= 3 // displacement is computed while the immediate value is fetched
+			}
+			s.setReg(y, s.fetch())
+		case 7:
+			switch {
+			case y < 4: // RLCA, RRCA, RLA, RRA
+				s.rotateA(y)
+			case y == 4:
+				s.daa()
+			case y == 5:
+				s.cpl()
+			case y == 6:
+				s.scf()
+			case y == 7:
+				s.ccf()
+			}
+		}
+	case 1:
+		switch {
+		case y == m && z == m: // HALT
+			s.halted = true
+		case y == m: // LD (HL),r; with index prefix h and l aren't substituted
+			s.setReg(m, s.plainReg(z))
+		case z == m: // LD r,(HL)
+			s.setPlainReg(y, s.reg(m))
+		default: // LD r,r'
+			s.setReg(y, s.reg(z))
+		}
+	case 2: // ALU r
+		s.alu(y, s.reg(z))
+	case 3:
+		switch z {
+		case 0: // RET cc
+			if s.condition(y) {
+				s.pc = s.pop16()
+				s.cycles += retCycles
+			}
+		case 1:
+			switch {
+			case q == 0: // POP rp2
+				s.setRP2(p, s.pop16())
+			case p == 0: // RET
+				s.pc = s.pop16()
+			case p == 1: // EXX
+				bc, de, hl := s.BC(), s.DE(), s.HL()
+				s.SetBC(s.bc2)
+				s.SetDE(s.de2)
+				s.SetHL(s.hl2)
+				s.bc2, s.de2, s.hl2 = bc, de, hl
+			case p == 2: // JP (HL)
+				s.pc = s.hl()
+			case p == 3: // LD SP,HL
+				s.sp = s.hl()
+			}
+		case 2: // JP cc,nn
+			address := s.fetch16()
+			if s.condition(y) {
+				s.pc = address
+			}
+		case 3:
+			switch y {
+			case 0: // JP nn
+				s.pc = s.fetch16()
+			case 2: // OUT (n),A
+				s.out(s.fetch(), s.a)
+			case 3: // IN A,(n)
+				s.a = s.in(s.fetch())
+			case 4: // EX (SP),HL
+				hl := s.hl()
+				s.setHL(s.read16(s.sp))
+				s.write16(s.sp, hl)
+			case 5: // EX DE,HL; never affected by index prefix
+				de := s.DE()
+				s.SetDE(s.HL())
+				s.SetHL(de)
+			case 6: // DI
+				s.iff1, s.iff2 = false, false
+			case 7: // EI
+				s.iff1, s.iff2 = true, true
+				s.eiDelay = true
+			}
+		case 4: // CALL cc,nn
+			address := s.fetch16()
+			if s.condition(y) {
+				s.call(address)
+				s.cycles += callCycles
+			}
+		case 5:
+			if q == 0 { // PUSH rp2
+				s.push16(s.rp2(p))
+			} else { // CALL nn
+				s.call(s.fetch16())
+			}
+		case 6: // ALU n
+			s.alu(y, s.fetch())
+		case 7: // RST y*8
+			s.call(uint16(y) * 8)
+		}
+	}
+}
+
+// executeCB runs CB prefixed opcode: rotations and shifts, BIT, RES and SET
+func (s *CPU) executeCB(opCode uint8) {
+	s.cycles += uint64(cyclesCB(opCode, false))
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+
+	val := s.reg(z)
+	switch x {
+	case 0:
+		s.setReg(z, s.rotate(y, val))
+	case 1:
+		s.bit(y, val)
+	case 2:
+		s.setReg(z, val&^(1<<y))
+	case 3:
+		s.setReg(z, val|1<<y)
+	}
+}
+
+// executeIndexedCB runs DD CB d op or FD CB d op; operations always work on (IX+d) or (IY+d) and
+// undocumented ones with register field other than (HL) copy the result to that register too
+func (s *CPU) executeIndexedCB() {
+	s.disp = s.indexBase() + uint16(int8(s.fetch()))
+	s.dispLoaded = true
+	opCode := s.fetch()
+	s.cycles += uint64(cyclesCB(opCode, true))
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+
+	val := s.mem.Read(s.disp)
+	var res uint8
+	switch x {
+	case 0:
+		res = s.rotate(y, val)
+	case 1:
+		s.bit(y, val)
+		return
+	case 2:
+		res = val &^ (1 << y)
+	case 3:
+		res = val | 1<<y
+	}
+
+	s.mem.Write(s.disp, res)
+	if z != m {
+		s.setPlainReg(z, res)
+	}
+}
+
+// executeED runs ED prefixed opcode; opcodes with no meaning work as NOP
+func (s *CPU) executeED(opCode uint8) {
+	s.cycles += uint64(cyclesED[opCode])
+	x, y, z := opCode>>6, opCode>>3&7, opCode&7
+	p, q := y>>1, y&1
+
+	switch {
+	case x == 1:
+		switch z {
+		case 0: // IN r,(C); IN (C) only sets flags
+			val := s.in(s.c)
+			if y != m {
+				s.setPlainReg(y, val)
+			}
+			s.f = s.f&flagC | szpTable[val]
+		case 1: // OUT (C),r; OUT (C),0 for (HL)
+			var val uint8
+			if y != m {
+				val = s.plainReg(y)
+			}
+			s.out(s.c, val)
+		case 2:
+			if q == 0 { // SBC HL,rp
+				s.sbc16(s.rp(p))
+			} else { // ADC HL,rp
+				s.adc16(s.rp(p))
+			}
+		case 3:
+			if q == 0 { // LD (nn),rp
+				s.write16(s.fetch16(), s.rp(p))
+			} else { // LD rp,(nn)
+				s.setRP(p, s.read16(s.fetch16()))
+			}
+		case 4: // NEG
+			val := s.a
+			s.a = 0
+			s.a = s.sub8(val, 0)
+		case 5: // RETN, RETI
+			s.iff1 = s.iff2
+			s.pc = s.pop16()
+		case 6: // IM 0, 1, 2
+			s.im = [...]uint8{0, 0, 1, 2}[y&3]
+		case 7:
+			switch y {
+			case 0: // LD I,A
+				s.i = s.a
+			case 1: // LD R,A
+				s.r = s.a
+			case 2: // LD A,I
+				s.ldAir(s.i)
+			case 3: // LD A,R
+				s.ldAir(s.r)
+			case 4: // RRD
+				val := s.mem.Read(s.HL())
+				s.mem.Write(s.HL(), s.a<<4|val>>4)
+				s.a = s.a&0xf0 | val&0x0f
+				s.f = s.f&flagC | szpTable[s.a]
+			case 5: // RLD
+				val := s.mem.Read(s.HL())
+				s.mem.Write(s.HL(), val<<4|s.a&0x0f)
+				s.a = s.a&0xf0 | val>>4
+				s.f = s.f&flagC | szpTable[s.a]
+			}
+		}
+	case x == 2 && y >= 4 && z <= 3:
+		s.block(y, z)
+	}
+}
+
+// ldAir loads I or R register to accumulator; parity flag reflects interrupts enable state
+func (s *CPU) ldAir(val uint8) {
+	s.a = val
+	s.f = s.f&flagC | sz(val)
+	if s.iff2 {
+		s.f |= flagPV
+	}
+}
+
+// block runs one step of block transfer, search, input or output instruction; y tells direction
+// and whether it repeats, z which of them it is
+func (s *CPU) block(y, z uint8) {
+	step := uint16(1)
+	if y&1 == 1 {
+		step = 0xffff // decrementing
+	}
+	repeat := y >= 6
+	hl := s.HL()
+
+	var again bool
+	switch z {
+	case 0: // LDI, LDD, LDIR, LDDR
+		val := s.mem.Read(hl)
+		s.mem.Write(s.DE(), val)
+		s.SetDE(s.DE() + step)
+		s.SetBC(s.BC() - 1)
+
+		n := val + s.a
+		s.f = s.f&(flagS|flagZ|flagC) | n&flagX | n<<4&flagY
+		if s.BC() != 0 {
+			s.f |= flagPV
+		}
+		again = s.BC() != 0
+	case 1: // CPI, CPD, CPIR, CPDR
+		val := s.mem.Read(hl)
+		res := s.a - val
+		s.SetBC(s.BC() - 1)
+
+		flags := s.f&flagC | sz(res)&^flagsXY | flagN | (s.a^val^res)&flagH
+		n := res - (flags&flagH)>>4
+		flags |= n&flagX | n<<4&flagY
+		if s.BC() != 0 {
+			flags |= flagPV
+		}
+		s.f = flags
+		again = s.BC() != 0 && res != 0
+	case 2: // INI, IND, INIR, INDR
+		s.mem.Write(hl, s.in(s.c))
+		s.b--
+		s.f = s.f&flagC | sz(s.b) | flagN
+		again = s.b != 0
+	case 3: // OUTI, OUTD, OTIR, OTDR
+		val := s.mem.Read(hl)
+		s.b--
+		s.out(s.c, val)
+		s.f = s.f&flagC | sz(s.b) | flagN
+		again = s.b != 0
+	}
+	s.SetHL(hl + step)
+
+	if repeat && again {
+		s.pc -= 2
+		s.cycles += repeatCycles
+	}
+}
+
+// condition reports whether one of eight conditions encoded in opcodes is met: NZ, Z, NC, C, PO,
+// PE, P and M
+func (s *CPU) condition(cc uint8) bool {
+	flag := [...]uint8{flagZ, flagC, flagPV, flagS}[cc>>1]
+	return (s.f&flag != 0) == (cc&1 == 1)
+}
+
+// jr jumps relative to the next instruction when condition is met
+func (s *CPU) jr(condition bool, offset uint8) {
+	if condition {
+		s.pc += uint16(int8(offset))
+		s.cycles += jrCycles
+	}
+}
+
+// call pushes address of the next instruction on the stack and jumps to provided address
+func (s *CPU) call(address uint16) {
+	s.push16(s.pc)
+	s.pc = address
+}
+
+// in reads from provided port; ports without handler read open bus
+func (s *CPU) in(port uint8) uint8 {
+	if handler := s.ports[port]; handler != nil {
+		return handler.In(port)
+	}
+
+	return 0xff
+}
+
+// out writes provided value to provided port
+func (s *CPU) out(port uint8, val uint8) {
+	if handler := s.ports[port]; handler != nil {
+		handler.Out(port, val)
+	}
+}
+
+// fetchOpcode reads opcode during M1 cycle, which also refreshes memory
+func (s *CPU) fetchOpcode() uint8 {
+	s.refresh()
+	return s.fetch()
+}
+
+// refresh increments the lower seven bits of R register
+func (s *CPU) refresh() {
+	s.r = s.r&0x80 | (s.r+1)&0x7f
+}
+
+// fetch reads the next instruction byte and advances pc past it; while serving an interrupt in
+// mode 0 bytes come from the data bus and pc stays put
+func (s *CPU) fetch() uint8 {
+	if s.injected != nil {
+		if len(s.injected) == 0 {
+			return 0xff
+		}
+		val := s.injected[0]
+		s.injected = s.injected[1:]
+		return val
+	}
+
+	val := s.mem.Read(s.pc)
+	s.pc++
+	return val
+}
+
+// fetch16 reads the next two instruction bytes as a little endian 16bit value
+func (s *CPU) fetch16() uint16 {
+	lo := s.fetch()
+	hi := s.fetch()
+	return pair(hi, lo)
+}
+
+func (s *CPU) read16(address uint16) uint16 {
+	return pair(s.mem.Read(address+1), s.mem.Read(address))
+}
+
+func (s *CPU) write16(address uint16, val uint16) {
+	s.mem.Write(address, uint8(val))
+	s.mem.Write(address+1, uint8(val>>8))
+}
+
+// push16 stores 16bit value on top of the stack with high byte at the higher address
+func (s *CPU) push16(val uint16) {
+	s.sp -= 2
+	s.write16(s.sp, val)
+}
+
+// pop16 loads 16bit value from top of the stack
+func (s *CPU) pop16() uint16 {
+	val := s.read16(s.sp)
+	s.sp += 2
+	return val
+}
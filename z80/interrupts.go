@@ -0,0 +1,83 @@
+package z80
+
+// Interrupt requests maskable interrupt with provided data placed on the bus: an instruction in
+// mode 0, nothing in mode 1 and low byte of vector table address in mode 2. The request stays
+// pending until CPU accepts it or it gets cleared; accepting it wakes CPU up from HALT.
+func (s *CPU) Interrupt(data ...uint8) {
+	s.intRequest = true
+	s.intData = data
+}
+
+// ClearInterrupt drops pending maskable interrupt request
+func (s *CPU) ClearInterrupt() {
+	s.intRequest = false
+	s.intData = nil
+}
+
+// NMI requests non-maskable interrupt; it's accepted before the next instruction whatever state
+// interrupts are in
+func (s *CPU) NMI() {
+	s.nmiRequest = true
+}
+
+// InterruptsEnabled reports whether CPU accepts maskable interrupts
+func (s *CPU) InterruptsEnabled() bool {
+	return s.iff1
+}
+
+// InterruptMode returns interrupt mode set with IM instruction
+func (s *CPU) InterruptMode() uint8 {
+	return s.im
+}
+
+// interruptPending reports whether there's a request CPU accepts
+func (s *CPU) interruptPending() bool {
+	return s.nmiRequest || s.iff1 && s.intRequest
+}
+
+// serveNMI calls 0x0066 keeping previous interrupts enable state in iff2, so RETN can restore it
+func (s *CPU) serveNMI() int {
+	s.nmiRequest = false
+	s.iff1 = false
+	s.halted = false
+	s.refresh()
+
+	s.call(0x0066)
+	s.cycles += nmiCycles
+	return nmiCycles
+}
+
+// serveInterrupt acknowledges pending maskable interrupt, disables further ones and handles it
+// according to current interrupt mode
+func (s *CPU) serveInterrupt() int {
+	data := s.intData
+	s.ClearInterrupt()
+	s.iff1, s.iff2 = false, false
+	s.halted = false
+	start := s.cycles
+
+	switch s.im {
+	case 0:
+		if data == nil {
+			data = []uint8{} // empty bus reads as RST 38h
+		}
+		s.injected = data
+		s.execute(s.fetchOpcode())
+		s.injected = nil
+		s.cycles += im0Cycles
+	case 1:
+		s.refresh()
+		s.call(0x0038)
+		s.cycles += im1Cycles
+	case 2:
+		var vector uint8 = 0xff
+		if len(data) > 0 {
+			vector = data[0]
+		}
+		s.refresh()
+		s.call(s.read16(pair(s.i, vector)))
+		s.cycles += im2Cycles
+	}
+
+	return int(s.cycles - start)
+}
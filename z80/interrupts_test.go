@@ -0,0 +1,97 @@
+package z80
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestInterrupt(t *testing.T) {
+	t.Run("when mode 0", func(t *testing.T) {
+		cpu := newLoaded()
+		cpu.pc = 0x0123
+		cpu.iff1, cpu.iff2 = true, true
+
+		cpu.Interrupt(0xcf) // RST 08h
+		spent, err := cpu.Step()
+		assert.Nil(t, err)
+		assert.Equal(t, uint16(0x0008), cpu.pc, "executes instruction from the data bus")
+		assert.Equal(t, uint16(0x0123), cpu.pop16(), "pushes address of interrupted instruction")
+		assert.False(t, cpu.InterruptsEnabled(), "disables interrupts")
+		assert.False(t, cpu.iff2, "resets both flip-flops")
+		assert.Equal(t, 13, spent)
+	})
+
+	t.Run("when mode 1", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x56, 0xfb, 0x00, 0x00) // IM 1; EI; NOP; NOP
+		cpu.Interrupt()
+
+		cpu.Step()
+		assert.Equal(t, uint8(1), cpu.InterruptMode())
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, uint16(4), cpu.pc, "executes one more instruction after EI")
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x0038), cpu.pc, "calls 0x0038")
+		assert.Equal(t, 13, spent)
+	})
+
+	t.Run("when mode 2", func(t *testing.T) {
+		cpu := newLoaded()
+		cpu.pc = 0x0123
+		cpu.i, cpu.im = 0x20, 2
+		cpu.iff1 = true
+		cpu.Load(0x2010, []byte{0x00, 0x30})
+
+		cpu.Interrupt(0x10)
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x3000), cpu.pc, "calls address from the vector table")
+		assert.Equal(t, uint16(0x0123), cpu.pop16(), "pushes address of interrupted instruction")
+		assert.Equal(t, 19, spent)
+	})
+
+	t.Run("when interrupts are disabled", func(t *testing.T) {
+		cpu := newLoaded()
+		cpu.im = 1
+
+		cpu.Interrupt()
+		cpu.Step()
+		assert.Equal(t, uint16(1), cpu.pc, "ignores interrupt")
+	})
+
+	t.Run("when CPU is halted", func(t *testing.T) {
+		cpu := newLoaded(0xfb, 0x76) // EI; HALT
+		cpu.im = 1
+
+		err := cpu.Run()
+		assert.Nil(t, err)
+		assert.True(t, cpu.Halted())
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, haltedCycles, spent, "executes NOPs")
+
+		cpu.Interrupt()
+		cpu.Step()
+		assert.False(t, cpu.Halted(), "wakes up")
+		assert.Equal(t, uint16(2), cpu.pop16(), "returns after HALT")
+	})
+}
+
+func TestNMI(t *testing.T) {
+	cpu := newLoaded()
+	cpu.Load(0x0066, []byte{0xed, 0x45}) // RETN
+	cpu.pc = 0x0123
+	cpu.iff1, cpu.iff2 = true, true
+
+	cpu.NMI()
+	spent, _ := cpu.Step()
+	assert.Equal(t, uint16(0x0066), cpu.pc, "calls 0x0066")
+	assert.False(t, cpu.iff1, "disables interrupts")
+	assert.True(t, cpu.iff2, "keeps previous state")
+	assert.Equal(t, 11, spent)
+
+	cpu.Step()
+	assert.Equal(t, uint16(0x0123), cpu.pc, "returns to interrupted instruction")
+	assert.True(t, cpu.iff1, "restores interrupts enable state")
+}
@@ -0,0 +1,160 @@
+package z80
+
+// indexBase returns register substituting hl in current instruction
+func (s *CPU) indexBase() uint16 {
+	switch s.index {
+	case useIX:
+		return s.ix
+	case useIY:
+		return s.iy
+	}
+
+	return s.HL()
+}
+
+// hl returns hl, ix or iy depending on prefix of current instruction
+func (s *CPU) hl() uint16 {
+	return s.indexBase()
+}
+
+// setHL sets hl, ix or iy depending on prefix of current instruction
+func (s *CPU) setHL(val uint16) {
+	switch s.index {
+	case useIX:
+		s.ix = val
+	case useIY:
+		s.iy = val
+	default:
+		s.SetHL(val)
+	}
+}
+
+// rp returns one of BC, DE, HL and SP registers pairs encoded in opcodes
+func (s *CPU) rp(code uint8) uint16 {
+	switch code {
+	case 0:
+		return s.BC()
+	case 1:
+		return s.DE()
+	case 2:
+		return s.hl()
+	}
+
+	return s.sp
+}
+
+func (s *CPU) setRP(code uint8, val uint16) {
+	switch code {
+	case 0:
+		s.SetBC(val)
+	case 1:
+		s.SetDE(val)
+	case 2:
+		s.setHL(val)
+	default:
+		s.sp = val
+	}
+}
+
+// rp2 returns one of BC, DE, HL and AF registers pairs encoded in PUSH and POP opcodes
+func (s *CPU) rp2(code uint8) uint16 {
+	if code == 3 {
+		return s.AF()
+	}
+
+	return s.rp(code)
+}
+
+func (s *CPU) setRP2(code uint8, val uint16) {
+	if code == 3 {
+		s.SetAF(val)
+	} else {
+		s.setRP(code, val)
+	}
+}
+
+// operand returns address of memory operand; with index prefix it's (IX+d) or (IY+d) and the
+// displacement gets fetched once per instruction
+func (s *CPU) operand() uint16 {
+	if s.index == useHL {
+		return s.HL()
+	}
+
+	if !s.dispLoaded {
+		s.disp = s.indexBase() + uint16(int8(s.fetch()))
+		s.dispLoaded = true
+		s.cycles += displacementCycles
+	}
+	return s.disp
+}
+
+// reg returns register encoded in opcodes; with index prefix h and l stand for halves of the index
+// register and m for (IX+d) or (IY+d)
+func (s *CPU) reg(code uint8) uint8 {
+	switch {
+	case code == m:
+		return s.mem.Read(s.operand())
+	case code == h && s.index != useHL:
+		return uint8(s.indexBase() >> 8)
+	case code == l && s.index != useHL:
+		return uint8(s.indexBase())
+	}
+
+	return s.plainReg(code)
+}
+
+func (s *CPU) setReg(code uint8, val uint8) {
+	switch {
+	case code == m:
+		s.mem.Write(s.operand(), val)
+	case code == h && s.index != useHL:
+		s.setHL(s.indexBase()&0x00ff | uint16(val)<<8)
+	case code == l && s.index != useHL:
+		s.setHL(s.indexBase()&0xff00 | uint16(val))
+	default:
+		s.setPlainReg(code, val)
+	}
+}
+
+// plainReg returns register encoded in opcodes ignoring index prefix
+func (s *CPU) plainReg(code uint8) uint8 {
+	switch code {
+	case b:
+		return s.b
+	case c:
+		return s.c
+	case d:
+		return s.d
+	case e:
+		return s.e
+	case h:
+		return s.h
+	case l:
+		return s.l
+	case m:
+		return s.mem.Read(s.HL())
+	}
+
+	return s.a
+}
+
+func (s *CPU) setPlainReg(code uint8, val uint8) {
+	switch code {
+	case b:
+		s.b = val
+	case c:
+		s.c = val
+	case d:
+		s.d = val
+	case e:
+		s.e = val
+	case h:
+		s.h = val
+	case l:
+		s.l = val
+	case m:
+		s.mem.Write(s.HL(), val)
+	default:
+		s.a = val
+	}
+}
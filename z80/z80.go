@@ -0,0 +1,223 @@
+package z80
+
+import (
+	"fmt"
+
+	"github.com/piokaczm/8080-emulator/eighty_eighty"
+)
+
+// flag bits of f register
+const (
+	flagC  = 1 << 0 // carry
+	flagN  = 1 << 1 // add/subtract
+	flagPV = 1 << 2 // parity/overflow
+	flagX  = 1 << 3 // undocumented copy of bit 3 of the result
+	flagH  = 1 << 4 // half carry
+	flagY  = 1 << 5 // undocumented copy of bit 5 of the result
+	flagZ  = 1 << 6 // zero
+	flagS  = 1 << 7 // sign
+
+	flagsXY = flagX | flagY
+)
+
+// register codes used in opcodes; m stands for memory cell addressed by hl, ix+d or iy+d
+const (
+	b = iota
+	c
+	d
+	e
+	h
+	l
+	m
+	a
+)
+
+// index registers substituting hl after DD and FD prefixes
+const (
+	useHL = iota
+	useIX
+	useIY
+)
+
+// CPU holds complete state of emulated Z80 processor
+type CPU struct {
+	a, f, b, c, d, e, h, l uint8
+	af2, bc2, de2, hl2     uint16 // alternate register set
+	ix, iy                 uint16
+	sp, pc                 uint16
+	i, r                   uint8
+
+	iff1, iff2 bool
+	im         uint8
+	eiDelay    bool // set by EI so the next instruction runs before any interrupt
+	intRequest bool
+	intData    []uint8 // data placed on the bus by interrupting device
+	nmiRequest bool
+	injected   []uint8 // instruction being executed instead of memory at pc in interrupt mode 0
+	halted     bool
+	cycles     uint64
+
+	mem   eighty_eighty.Bus
+	ports [256]eighty_eighty.IOHandler
+
+	index      int // register substituting hl in current instruction
+	disp       uint16
+	dispLoaded bool
+}
+
+// Option configures CPU created with New
+type Option func(*CPU)
+
+// WithBus makes CPU use provided memory bus instead of flat 64K RAM
+func WithBus(bus eighty_eighty.Bus) Option {
+	return func(s *CPU) {
+		s.mem = bus
+	}
+}
+
+// WithPorts attaches provided handler to ports from first to last inclusive
+func WithPorts(first, last uint8, handler eighty_eighty.IOHandler) Option {
+	return func(s *CPU) {
+		s.AttachPorts(first, last, handler)
+	}
+}
+
+// New returns fresh Z80 CPU; by default it's attached to 64K of zeroed RAM
+func New(opts ...Option) *CPU {
+	s := &CPU{
+		mem: eighty_eighty.NewRAM(0x10000),
+		sp:  0xffff,
+		f:   0xff,
+		a:   0xff,
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+// AttachPorts attaches provided handler to ports from first to last inclusive; Z80 puts 16bit
+// address on the bus during I/O, handlers get its low byte
+func (s *CPU) AttachPorts(first, last uint8, handler eighty_eighty.IOHandler) {
+	for port := int(first); port <= int(last); port++ {
+		s.ports[port] = handler
+	}
+}
+
+// Run executes instructions until CPU halts with no interrupt to wake it up or one of them fails
+func (s *CPU) Run() error {
+	for {
+		if _, err := s.Step(); err != nil {
+			return err
+		}
+		if s.Idle() {
+			return nil
+		}
+	}
+}
+
+// Halted reports whether CPU executed HALT and waits for an interrupt
+func (s *CPU) Halted() bool { return s.halted }
+
+// Idle reports whether CPU is halted with no interrupt to wake it up
+func (s *CPU) Idle() bool { return s.halted && !s.interruptPending() }
+
+// Cycles returns number of cycles executed so far
+func (s *CPU) Cycles() uint64 { return s.cycles }
+
+// A returns accumulator value
+func (s *CPU) A() uint8 { return s.a }
+
+// F returns flags register
+func (s *CPU) F() uint8 { return s.f }
+
+// C returns value of register c
+func (s *CPU) C() uint8 { return s.c }
+
+// E returns value of register e
+func (s *CPU) E() uint8 { return s.e }
+
+// AF returns value of af registers pair
+func (s *CPU) AF() uint16 { return pair(s.a, s.f) }
+
+// BC returns value of bc registers pair
+func (s *CPU) BC() uint16 { return pair(s.b, s.c) }
+
+// DE returns value of de registers pair
+func (s *CPU) DE() uint16 { return pair(s.d, s.e) }
+
+// HL returns value of hl registers pair
+func (s *CPU) HL() uint16 { return pair(s.h, s.l) }
+
+// IX returns value of ix index register
+func (s *CPU) IX() uint16 { return s.ix }
+
+// IY returns value of iy index register
+func (s *CPU) IY() uint16 { return s.iy }
+
+// SP returns stack pointer
+func (s *CPU) SP() uint16 { return s.sp }
+
+// PC returns program counter
+func (s *CPU) PC() uint16 { return s.pc }
+
+// SetAF sets value of af registers pair
+func (s *CPU) SetAF(val uint16) { s.a, s.f = split(val) }
+
+// SetBC sets value of bc registers pair
+func (s *CPU) SetBC(val uint16) { s.b, s.c = split(val) }
+
+// SetDE sets value of de registers pair
+func (s *CPU) SetDE(val uint16) { s.d, s.e = split(val) }
+
+// SetHL sets value of hl registers pair
+func (s *CPU) SetHL(val uint16) { s.h, s.l = split(val) }
+
+// SetIX sets value of ix index register
+func (s *CPU) SetIX(val uint16) { s.ix = val }
+
+// SetIY sets value of iy index register
+func (s *CPU) SetIY(val uint16) { s.iy = val }
+
+// SetSP sets stack pointer
+func (s *CPU) SetSP(val uint16) { s.sp = val }
+
+// SetPC sets program counter
+func (s *CPU) SetPC(val uint16) { s.pc = val }
+
+// Load writes provided data to memory bus starting at provided address
+func (s *CPU) Load(address uint16, data []byte) error {
+	if int(address)+len(data) > 0x10000 {
+		return fmt.Errorf("%d bytes don't fit in memory at %#04x", len(data), address)
+	}
+
+	for i, val := range data {
+		s.mem.Write(address+uint16(i), val)
+	}
+	return nil
+}
+
+// ReadMemory returns value stored at provided address
+func (s *CPU) ReadMemory(address uint16) uint8 {
+	return s.mem.Read(address)
+}
+
+// WriteMemory stores value at provided address
+func (s *CPU) WriteMemory(address uint16, val uint8) {
+	s.mem.Write(address, val)
+}
+
+// Bus returns memory bus CPU is attached to
+func (s *CPU) Bus() eighty_eighty.Bus {
+	return s.mem
+}
+
+func pair(hi, lo uint8) uint16 {
+	return uint16(hi)<<8 | uint16(lo)
+}
+
+func split(val uint16) (uint8, uint8) {
+	return uint8(val >> 8), uint8(val)
+}
@@ -0,0 +1,495 @@
+package z80
+
+import (
+	"testing"
+
+	"github.com/piokaczm/8080-emulator/eighty_eighty"
+	"github.com/stretchr/testify/assert"
+)
+
+// newLoaded returns CPU with provided program loaded at 0 and stack at 0x2400
+func newLoaded(program ...byte) *CPU {
+	cpu := New()
+	cpu.Load(0, program)
+	cpu.sp = 0x2400
+	return cpu
+}
+
+func TestArithmetic(t *testing.T) {
+	testCases := []struct {
+		name     string
+		program  []byte
+		a, f     uint8
+		val      uint8
+		expected uint8
+		flags    uint8
+	}{
+		{"ADD A,B with overflow", []byte{0x80}, 0x7f, 0x00, 0x01, 0x80, flagS | flagH | flagPV},
+		{"ADD A,B with carry", []byte{0x80}, 0xff, 0x00, 0x01, 0x00, flagZ | flagH | flagC},
+		{"ADC A,B", []byte{0x88}, 0x0e, flagC, 0x01, 0x10, flagH},
+		{"SUB B with borrow", []byte{0x90}, 0x00, 0x00, 0x01, 0xff, flagS | flagY | flagH | flagX | flagN | flagC},
+		{"SUB B with overflow", []byte{0x90}, 0x80, 0x00, 0x01, 0x7f, flagY | flagH | flagX | flagPV | flagN},
+		{"SBC A,B", []byte{0x98}, 0x10, flagC, 0x0f, 0x00, flagZ | flagH | flagN},
+		{"AND B", []byte{0xa0}, 0xf0, flagC, 0x3c, 0x30, flagY | flagH | flagPV},
+		{"XOR B", []byte{0xa8}, 0xff, flagC, 0xff, 0x00, flagZ | flagPV},
+		{"OR B", []byte{0xb0}, 0x01, 0x00, 0x02, 0x03, flagPV},
+		{"CP B", []byte{0xb8}, 0x10, 0x00, 0x28, 0x10, flagS | flagY | flagH | flagX | flagN | flagC},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			cpu := newLoaded(testCase.program...)
+			cpu.a, cpu.f, cpu.b = testCase.a, testCase.f, testCase.val
+
+			_, err := cpu.Step()
+			assert.Nil(t, err)
+			assert.Equal(t, testCase.expected, cpu.a, "sets accumulator")
+			assert.Equal(t, testCase.flags, cpu.f, "sets flags")
+		})
+	}
+}
+
+func TestIncDec(t *testing.T) {
+	t.Run("when INC overflows", func(t *testing.T) {
+		cpu := newLoaded(0x04) // INC B
+		cpu.b, cpu.f = 0x7f, flagC
+
+		cpu.Step()
+		assert.Equal(t, uint8(0x80), cpu.b)
+		assert.Equal(t, uint8(flagS|flagH|flagPV|flagC), cpu.f, "sets overflow and keeps carry")
+	})
+
+	t.Run("when DEC overflows", func(t *testing.T) {
+		cpu := newLoaded(0x05) // DEC B
+		cpu.b, cpu.f = 0x80, 0
+
+		cpu.Step()
+		assert.Equal(t, uint8(0x7f), cpu.b)
+		assert.Equal(t, uint8(flagY|flagH|flagX|flagPV|flagN), cpu.f, "sets overflow and subtraction")
+	})
+}
+
+func TestDAA(t *testing.T) {
+	t.Run("after addition", func(t *testing.T) {
+		cpu := newLoaded(0x80, 0x27) // ADD A,B; DAA
+		cpu.a, cpu.b = 0x15, 0x27
+
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, uint8(0x42), cpu.a, "adjusts sum to BCD")
+	})
+
+	t.Run("after subtraction", func(t *testing.T) {
+		cpu := newLoaded(0x90, 0x27) // SUB B; DAA
+		cpu.a, cpu.b = 0x42, 0x15
+
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, uint8(0x27), cpu.a, "adjusts difference to BCD")
+		assert.NotZero(t, cpu.f&flagN, "keeps subtraction flag")
+	})
+}
+
+func Test16BitArithmetic(t *testing.T) {
+	t.Run("when ADD HL,DE", func(t *testing.T) {
+		cpu := newLoaded(0x19)
+		cpu.SetHL(0x0fff)
+		cpu.SetDE(0x0001)
+		cpu.f = flagZ | flagS
+
+		cpu.Step()
+		assert.Equal(t, uint16(0x1000), cpu.HL())
+		assert.Equal(t, uint8(flagZ|flagS|flagH), cpu.f, "sets half carry from bit 11 and keeps sign and zero")
+	})
+
+	t.Run("when ADC HL,BC", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x4a)
+		cpu.SetHL(0x7fff)
+		cpu.SetBC(0x0000)
+		cpu.f = flagC
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x8000), cpu.HL())
+		assert.Equal(t, uint8(flagS|flagH|flagPV), cpu.f, "sets overflow")
+		assert.Equal(t, 15, spent)
+	})
+
+	t.Run("when SBC HL,DE", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x52)
+		cpu.SetHL(0x1000)
+		cpu.SetDE(0x1000)
+		cpu.f = 0
+
+		cpu.Step()
+		assert.Equal(t, uint16(0x0000), cpu.HL())
+		assert.Equal(t, uint8(flagZ|flagN), cpu.f, "sets zero for the whole pair")
+	})
+}
+
+func TestRelativeJumps(t *testing.T) {
+	t.Run("when JR backwards", func(t *testing.T) {
+		cpu := newLoaded()
+		cpu.pc = 0x0100
+		cpu.Load(0x0100, []byte{0x18, 0xfe}) // JR $
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x0100), cpu.pc, "jumps relative to the next instruction")
+		assert.Equal(t, 12, spent)
+	})
+
+	t.Run("when JR NZ is not taken", func(t *testing.T) {
+		cpu := newLoaded(0x20, 0x10)
+		cpu.f = flagZ
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(2), cpu.pc, "skips the offset")
+		assert.Equal(t, 7, spent)
+	})
+
+	t.Run("when DJNZ", func(t *testing.T) {
+		cpu := newLoaded(0x10, 0xfe) // DJNZ $
+		cpu.b = 3
+
+		total := 0
+		for cpu.pc == 0 {
+			spent, _ := cpu.Step()
+			total += spent
+		}
+		assert.Zero(t, cpu.b, "loops until b reaches zero")
+		assert.Equal(t, 13+13+8, total)
+	})
+}
+
+func TestExchanges(t *testing.T) {
+	t.Run("when EX AF,AF' and EXX", func(t *testing.T) {
+		cpu := newLoaded(0x08, 0xd9)
+		cpu.SetAF(0x1122)
+		cpu.SetBC(0x3344)
+		cpu.SetDE(0x5566)
+		cpu.SetHL(0x7788)
+		cpu.af2, cpu.bc2, cpu.de2, cpu.hl2 = 0xaabb, 0xccdd, 0xeeff, 0x0102
+
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, []uint16{0xaabb, 0xccdd, 0xeeff, 0x0102}, []uint16{cpu.AF(), cpu.BC(), cpu.DE(), cpu.HL()}, "switches to alternate set")
+		assert.Equal(t, []uint16{0x1122, 0x3344, 0x5566, 0x7788}, []uint16{cpu.af2, cpu.bc2, cpu.de2, cpu.hl2}, "keeps main set as alternate")
+	})
+
+	t.Run("when EX DE,HL has index prefix", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0xeb)
+		cpu.SetDE(0x1234)
+		cpu.SetHL(0x5678)
+		cpu.ix = 0x9abc
+
+		cpu.Step()
+		assert.Equal(t, uint16(0x5678), cpu.DE(), "still exchanges hl")
+		assert.Equal(t, uint16(0x9abc), cpu.ix, "leaves ix alone")
+	})
+}
+
+func TestIndexRegisters(t *testing.T) {
+	t.Run("when LD IX,nn", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0x21, 0x34, 0x12)
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x1234), cpu.ix)
+		assert.Equal(t, uint16(4), cpu.pc)
+		assert.Equal(t, 14, spent)
+	})
+
+	t.Run("when LD (IY+d),n", func(t *testing.T) {
+		cpu := newLoaded(0xfd, 0x36, 0xfe, 0x3e)
+		cpu.iy = 0x2002
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(0x3e), cpu.mem.Read(0x2000), "stores value at negative displacement")
+		assert.Equal(t, uint16(4), cpu.pc)
+		assert.Equal(t, 19, spent)
+	})
+
+	t.Run("when LD H,(IX+d)", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0x66, 0x05)
+		cpu.ix = 0x2000
+		cpu.mem.Write(0x2005, 0x3e)
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(0x3e), cpu.h, "loads real h register")
+		assert.Equal(t, uint16(0x2000), cpu.ix, "leaves ix alone")
+		assert.Equal(t, 19, spent)
+	})
+
+	t.Run("when LD IXH,n", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0x26, 0x3e)
+		cpu.ix = 0x1234
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x3e34), cpu.ix, "sets high byte of ix")
+		assert.Equal(t, 11, spent)
+	})
+
+	t.Run("when INC (IX+d)", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0x34, 0x01)
+		cpu.ix = 0x2000
+		cpu.mem.Write(0x2001, 0xff)
+
+		spent, _ := cpu.Step()
+		assert.Zero(t, cpu.mem.Read(0x2001))
+		assert.NotZero(t, cpu.f&flagZ)
+		assert.Equal(t, 23, spent)
+	})
+
+	t.Run("when ADD IX,IX", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0x29)
+		cpu.ix = 0x1111
+		cpu.SetHL(0x2222)
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(0x2222), cpu.ix, "adds ix to itself")
+		assert.Equal(t, 15, spent)
+	})
+
+	t.Run("when PUSH IY and POP IX", func(t *testing.T) {
+		cpu := newLoaded(0xfd, 0xe5, 0xdd, 0xe1)
+		cpu.iy = 0x1234
+
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, uint16(0x1234), cpu.ix)
+	})
+
+	t.Run("when prefix is not followed by instruction using hl", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0x04) // INC B
+		cpu.ix = 0x1234
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(1), cpu.b, "executes instruction normally")
+		assert.Equal(t, 8, spent, "spends cycles on the prefix")
+	})
+}
+
+func TestBitInstructions(t *testing.T) {
+	t.Run("when RLC B", func(t *testing.T) {
+		cpu := newLoaded(0xcb, 0x00)
+		cpu.b = 0x81
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(0x03), cpu.b)
+		assert.Equal(t, uint8(flagPV|flagC), cpu.f)
+		assert.Equal(t, 8, spent)
+	})
+
+	t.Run("when SRA (HL)", func(t *testing.T) {
+		cpu := newLoaded(0xcb, 0x2e)
+		cpu.SetHL(0x2000)
+		cpu.mem.Write(0x2000, 0x81)
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(0xc0), cpu.mem.Read(0x2000), "keeps sign bit")
+		assert.NotZero(t, cpu.f&flagC)
+		assert.Equal(t, 15, spent)
+	})
+
+	t.Run("when BIT 7,A", func(t *testing.T) {
+		cpu := newLoaded(0xcb, 0x7f, 0xcb, 0x47)
+		cpu.a, cpu.f = 0x80, flagC
+
+		cpu.Step()
+		assert.Equal(t, uint8(flagS|flagH|flagC), cpu.f, "resets zero for set bit")
+
+		cpu.Step() // BIT 0,A
+		assert.Equal(t, uint8(flagZ|flagH|flagPV|flagC), cpu.f, "sets zero for reset bit")
+	})
+
+	t.Run("when SET and RES", func(t *testing.T) {
+		cpu := newLoaded(0xcb, 0xd8, 0xcb, 0x80) // SET 3,B; RES 0,B
+		cpu.b = 0x01
+
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, uint8(0x08), cpu.b)
+	})
+
+	t.Run("when SET 1,(IX+d)", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0xcb, 0x02, 0xce)
+		cpu.ix = 0x2000
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(0x02), cpu.mem.Read(0x2002))
+		assert.Equal(t, uint16(4), cpu.pc)
+		assert.Equal(t, 23, spent)
+	})
+
+	t.Run("when undocumented RLC (IY+d),C", func(t *testing.T) {
+		cpu := newLoaded(0xfd, 0xcb, 0xff, 0x01)
+		cpu.iy = 0x2001
+		cpu.mem.Write(0x2000, 0x80)
+
+		cpu.Step()
+		assert.Equal(t, uint8(0x01), cpu.mem.Read(0x2000), "rotates memory")
+		assert.Equal(t, uint8(0x01), cpu.c, "copies the result to register")
+	})
+
+	t.Run("when BIT 0,(IX+d)", func(t *testing.T) {
+		cpu := newLoaded(0xdd, 0xcb, 0x00, 0x46)
+		cpu.ix = 0x2000
+
+		spent, _ := cpu.Step()
+		assert.NotZero(t, cpu.f&flagZ)
+		assert.Equal(t, 20, spent)
+	})
+}
+
+func TestBlockInstructions(t *testing.T) {
+	t.Run("when LDIR", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0xb0)
+		cpu.Load(0x2000, []byte{1, 2, 3})
+		cpu.SetHL(0x2000)
+		cpu.SetDE(0x3000)
+		cpu.SetBC(3)
+
+		total := 0
+		for cpu.pc == 0 {
+			spent, _ := cpu.Step()
+			total += spent
+		}
+		assert.Equal(t, []uint8{1, 2, 3}, []uint8{cpu.mem.Read(0x3000), cpu.mem.Read(0x3001), cpu.mem.Read(0x3002)})
+		assert.Equal(t, uint16(0x2003), cpu.HL())
+		assert.Equal(t, uint16(0x3003), cpu.DE())
+		assert.Zero(t, cpu.BC())
+		assert.Zero(t, cpu.f&flagPV, "resets parity when bc reaches zero")
+		assert.Equal(t, 21+21+16, total)
+	})
+
+	t.Run("when CPDR finds value", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0xb9)
+		cpu.Load(0x2000, []byte{0x3e, 0x00, 0x00})
+		cpu.SetHL(0x2002)
+		cpu.SetBC(0x10)
+		cpu.a = 0x3e
+
+		for cpu.pc == 0 {
+			cpu.Step()
+		}
+		assert.Equal(t, uint16(0x1fff), cpu.HL(), "stops after the match")
+		assert.Equal(t, uint16(0x0d), cpu.BC())
+		assert.NotZero(t, cpu.f&flagZ, "sets zero on match")
+		assert.NotZero(t, cpu.f&flagPV, "sets parity while bc is not zero")
+	})
+
+	t.Run("when OTIR", func(t *testing.T) {
+		var written []uint8
+		cpu := New(WithPorts(0x10, 0x10, eighty_eighty.PortFuncs{OutFunc: func(port uint8, val uint8) { written = append(written, val) }}))
+		cpu.Load(0, []byte{0xed, 0xb3})
+		cpu.Load(0x2000, []byte{'h', 'i'})
+		cpu.SetHL(0x2000)
+		cpu.b, cpu.c = 2, 0x10
+
+		for cpu.pc == 0 {
+			cpu.Step()
+		}
+		assert.Equal(t, []uint8("hi"), written)
+		assert.NotZero(t, cpu.f&flagZ)
+	})
+}
+
+func TestMiscED(t *testing.T) {
+	t.Run("when NEG", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x44)
+		cpu.a = 0x80
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint8(0x80), cpu.a)
+		assert.Equal(t, uint8(flagS|flagPV|flagN|flagC), cpu.f)
+		assert.Equal(t, 8, spent)
+	})
+
+	t.Run("when RLD", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x6f)
+		cpu.a = 0x7a
+		cpu.SetHL(0x2000)
+		cpu.mem.Write(0x2000, 0x31)
+
+		cpu.Step()
+		assert.Equal(t, uint8(0x73), cpu.a)
+		assert.Equal(t, uint8(0x1a), cpu.mem.Read(0x2000))
+	})
+
+	t.Run("when LD A,R", func(t *testing.T) {
+		cpu := newLoaded(0x00, 0x00, 0xed, 0x5f)
+		cpu.iff2 = true
+
+		cpu.Step()
+		cpu.Step()
+		cpu.Step()
+		assert.Equal(t, uint8(4), cpu.a, "counts opcode fetches")
+		assert.NotZero(t, cpu.f&flagPV, "reports interrupts enable state")
+	})
+
+	t.Run("when LD (nn),DE and LD SP,(nn)", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x53, 0x00, 0x20, 0xed, 0x7b, 0x00, 0x20)
+		cpu.SetDE(0x1234)
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, 20, spent)
+		cpu.Step()
+		assert.Equal(t, uint16(0x1234), cpu.sp)
+	})
+
+	t.Run("when IN B,(C)", func(t *testing.T) {
+		cpu := New(WithPorts(0x10, 0x10, eighty_eighty.PortFuncs{InFunc: func(port uint8) uint8 { return 0x00 }}))
+		cpu.Load(0, []byte{0xed, 0x40})
+		cpu.b, cpu.c, cpu.f = 0xff, 0x10, flagC
+
+		cpu.Step()
+		assert.Zero(t, cpu.b)
+		assert.Equal(t, uint8(flagZ|flagPV|flagC), cpu.f, "sets flags from read value")
+	})
+
+	t.Run("when opcode has no meaning", func(t *testing.T) {
+		cpu := newLoaded(0xed, 0x00)
+
+		spent, _ := cpu.Step()
+		assert.Equal(t, uint16(2), cpu.pc, "skips it")
+		assert.Equal(t, 8, spent)
+	})
+}
+
+func TestConditions(t *testing.T) {
+	testCases := []struct {
+		opCode uint8
+		f      uint8
+		taken  bool
+	}{
+		{0xc2, 0, true}, {0xc2, flagZ, false}, // JP NZ
+		{0xca, flagZ, true},   // JP Z
+		{0xd2, flagC, false},  // JP NC
+		{0xda, flagC, true},   // JP C
+		{0xe2, flagPV, false}, // JP PO
+		{0xea, flagPV, true},  // JP PE
+		{0xf2, flagS, false},  // JP P
+		{0xfa, flagS, true},   // JP M
+	}
+
+	for _, testCase := range testCases {
+		cpu := newLoaded(testCase.opCode, 0x00, 0x20)
+		cpu.f = testCase.f
+
+		cpu.Step()
+		assert.Equal(t, testCase.taken, cpu.pc == 0x2000, "opcode %#02x with flags %08b", testCase.opCode, testCase.f)
+	}
+}
+
+func TestCallAndReturn(t *testing.T) {
+	cpu := newLoaded(0xcc, 0x00, 0x20) // CALL Z,0x2000
+	cpu.Load(0x2000, []byte{0xc8})     // RET Z
+	cpu.f = flagZ
+
+	spent, _ := cpu.Step()
+	assert.Equal(t, uint16(0x2000), cpu.pc)
+	assert.Equal(t, 17, spent)
+
+	spent, _ = cpu.Step()
+	assert.Equal(t, uint16(3), cpu.pc)
+	assert.Equal(t, uint16(0x2400), cpu.sp)
+	assert.Equal(t, 11, spent)
+}
@@ -0,0 +1,46 @@
+package z80
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/piokaczm/8080-emulator/internal/cpmtest"
+	"github.com/stretchr/testify/assert"
+)
+
+// exercisers take tens of billions of cycles, so this only catches runaway programs
+const zexCycleLimit = 100000000000
+
+// TestExercisers runs Z80 instruction exercisers placed in testdata; they aren't distributed with
+// the repository, see testdata/README.md for fetching them
+func TestExercisers(t *testing.T) {
+	for _, file := range []string{"zexdoc.com", "zexall.com"} {
+		t.Run(file, func(t *testing.T) {
+			program := cpmtest.Program(t, filepath.Join("testdata", file))
+			if testing.Short() {
+				t.Skipf("%s takes minutes to run", file)
+			}
+
+			output := cpmtest.Run(t, New(), program, zexCycleLimit)
+			t.Log(output)
+			assert.Contains(t, output, "Tests complete", "reports finishing")
+			assert.NotContains(t, output, "ERROR", "reports no errors")
+		})
+	}
+}
+
+func TestRunCPM(t *testing.T) {
+	program := []byte{
+		0x11, 0x11, 0x01, // LD DE,message
+		0x0e, 0x09, // LD C,9
+		0xcd, 0x05, 0x00, // CALL BDOS
+		0x0e, 0x02, // LD C,2
+		0x1e, '!', // LD E,'!'
+		0xcd, 0x05, 0x00, // CALL BDOS
+		0x18, 0x03, // JR exit
+		'O', 'K', '$', // message
+		0xc3, 0x00, 0x00, // exit: JP 0
+	}
+
+	assert.Equal(t, "OK!", cpmtest.Run(t, New(), program, zexCycleLimit), "captures console output until warm boot")
+}